@@ -0,0 +1,517 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The plural category of the number, see
+// https://www.unicode.org/cldr/charts/latest/supplemental/language_plural_rules.html
+type PluralForm uint8
+
+const (
+	PluralOther PluralForm = iota
+	PluralOne
+	PluralFew
+	PluralMany
+)
+
+// The message of the catalog. The plural forms are chosen by the last
+// integer argument of the message, e.g. the upper bound of the range,
+// and fall back to Other when not specified
+type Message struct {
+	Other string
+	One   string
+	Few   string
+	Many  string
+}
+
+// Holds the hint messages of the language by their stable IDs, e.g.
+//
+//	&validator.Catalog{
+//		Lang:   "de",
+//		Plural: validator.PluralEnglish,
+//		Messages: map[string]validator.Message{
+//			"min_str_len": {Other: "muss mindestens %v Zeichen enthalten"},
+//		},
+//	}
+//
+// The messages missing from the catalog remain in English
+type Catalog struct {
+	// Language tag, e.g. "uk" or "pt-BR"
+	Lang string
+
+	// Plural rule of the language. Only Other is used when nil
+	Plural func(n uint64) PluralForm
+
+	Messages map[string]Message
+}
+
+// The stable IDs of the messages
+var messageCodes = map[string]string{
+	MsgMinStrLen:      "min_str_len",
+	MsgMaxStrLen:      "max_str_len",
+	MsgEqStrLen:       "eq_str_len",
+	MsgRangeStrLen:    "range_str_len",
+	MsgMinSetLen:      "min_set_len",
+	MsgMaxSetLen:      "max_set_len",
+	MsgEqSetLen:       "eq_set_len",
+	MsgRangeSetLen:    "range_set_len",
+	MsgMinBytes:       "min_bytes",
+	MsgMaxBytes:       "max_bytes",
+	MsgEqBytes:        "eq_bytes",
+	MsgRangeBytes:     "range_bytes",
+	MsgMin:            "min",
+	MsgMax:            "max",
+	MsgEq:             "eq",
+	MsgRange:          "range",
+	MsgIn:             "in",
+	MsgNotIn:          "not_in",
+	MsgContains:       "contains",
+	MsgNotContains:    "not_contains",
+	MsgPrefix:         "prefix",
+	MsgSuffix:         "suffix",
+	MsgNotPrefix:      "not_prefix",
+	MsgNotSuffix:      "not_suffix",
+	MsgNotMatch:       "not_match",
+	MsgNotRule:        "not_rule",
+	MsgAlpha:          "alpha",
+	MsgAlphaASCII:     "alpha_ascii",
+	MsgAlnum:          "alnum",
+	MsgAlnumASCII:     "alnum_ascii",
+	MsgNumeric:        "numeric",
+	MsgASCII:          "ascii",
+	MsgPrintable:      "printable",
+	MsgPrintableASCII: "printable_ascii",
+	MsgLower:          "lower",
+	MsgUpper:          "upper",
+	MsgNoSpace:        "no_space",
+	MsgUTF8:           "utf8",
+	MsgNotValid:       "not_valid",
+	MsgEmpty:          "empty",
+	MsgUnsupportType:  "unsupported_type",
+	MsgInvalidValue:   "invalid_value",
+	MsgInvalidRule:    "invalid_rule",
+	MsgInvalidBodyVal: "invalid_body_value",
+	MsgTruncated:      "truncated",
+	MsgAnyOf:          "any_of",
+	MsgOneOf:          "one_of",
+
+	MsgFieldsMin:       "fields_min",
+	MsgFieldsMax:       "fields_max",
+	MsgFieldsEq:        "fields_eq",
+	MsgFieldsRange:     "fields_range",
+	MsgFieldsAllOrNone: "fields_all_or_none",
+}
+
+var messageFormats = func() map[string]string {
+	formats := make(map[string]string, len(messageCodes))
+
+	for format, code := range messageCodes {
+		formats[code] = format
+	}
+
+	return formats
+}()
+
+// The plural rule of English and the languages alike, e.g. German
+func PluralEnglish(n uint64) PluralForm {
+	if n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// The plural rule of Ukrainian and the languages alike, e.g. Russian
+func PluralUkrainian(n uint64) PluralForm {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return PluralOne
+
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	}
+
+	return PluralMany
+}
+
+var English = &Catalog{
+	Lang:   "en",
+	Plural: PluralEnglish,
+	Messages: map[string]Message{
+		"min_str_len":        {One: "must contain at least %v character", Other: MsgMinStrLen},
+		"max_str_len":        {One: "must contain up to %v character", Other: MsgMaxStrLen},
+		"eq_str_len":         {One: "must contain exactly %v character", Other: MsgEqStrLen},
+		"range_str_len":      {One: "must contain %v..%v character", Other: MsgRangeStrLen},
+		"min_set_len":        {One: "must contain at least %v item", Other: MsgMinSetLen},
+		"max_set_len":        {One: "must contain up to %v item", Other: MsgMaxSetLen},
+		"eq_set_len":         {One: "must contain exactly %v item", Other: MsgEqSetLen},
+		"range_set_len":      {One: "must contain %v..%v item", Other: MsgRangeSetLen},
+		"min_bytes":          {One: "must contain at least %v byte", Other: MsgMinBytes},
+		"max_bytes":          {One: "must contain up to %v byte", Other: MsgMaxBytes},
+		"eq_bytes":           {One: "must contain exactly %v byte", Other: MsgEqBytes},
+		"range_bytes":        {One: "must contain %v..%v byte", Other: MsgRangeBytes},
+		"min":                {Other: MsgMin},
+		"max":                {Other: MsgMax},
+		"eq":                 {Other: MsgEq},
+		"range":              {Other: MsgRange},
+		"in":                 {Other: MsgIn},
+		"not_in":             {Other: MsgNotIn},
+		"contains":           {Other: MsgContains},
+		"not_contains":       {Other: MsgNotContains},
+		"prefix":             {Other: MsgPrefix},
+		"suffix":             {Other: MsgSuffix},
+		"not_prefix":         {Other: MsgNotPrefix},
+		"not_suffix":         {Other: MsgNotSuffix},
+		"not_match":          {Other: MsgNotMatch},
+		"not_rule":           {Other: MsgNotRule},
+		"alpha":              {Other: MsgAlpha},
+		"alpha_ascii":        {Other: MsgAlphaASCII},
+		"alnum":              {Other: MsgAlnum},
+		"alnum_ascii":        {Other: MsgAlnumASCII},
+		"numeric":            {Other: MsgNumeric},
+		"ascii":              {Other: MsgASCII},
+		"printable":          {Other: MsgPrintable},
+		"printable_ascii":    {Other: MsgPrintableASCII},
+		"lower":              {Other: MsgLower},
+		"upper":              {Other: MsgUpper},
+		"no_space":           {Other: MsgNoSpace},
+		"utf8":               {Other: MsgUTF8},
+		"not_valid":          {Other: MsgNotValid},
+		"empty":              {Other: MsgEmpty},
+		"unsupported_type":   {Other: MsgUnsupportType},
+		"invalid_value":      {Other: MsgInvalidValue},
+		"invalid_rule":       {Other: MsgInvalidRule},
+		"invalid_body_value": {Other: MsgInvalidBodyVal},
+		"truncated":          {Other: MsgTruncated},
+		"any_of":             {Other: MsgAnyOf},
+		"one_of":             {Other: MsgOneOf},
+		"fields_min":         {Other: MsgFieldsMin},
+		"fields_max":         {Other: MsgFieldsMax},
+		"fields_eq":          {Other: MsgFieldsEq},
+		"fields_range":       {Other: MsgFieldsRange},
+		"fields_all_or_none": {Other: MsgFieldsAllOrNone},
+	},
+}
+
+var Ukrainian = &Catalog{
+	Lang:   "uk",
+	Plural: PluralUkrainian,
+	Messages: map[string]Message{
+		"min_str_len": {
+			One:   "має містити щонайменше %v символ",
+			Few:   "має містити щонайменше %v символи",
+			Many:  "має містити щонайменше %v символів",
+			Other: "має містити щонайменше %v символу",
+		},
+		"max_str_len": {
+			One:   "має містити не більше %v символу",
+			Few:   "має містити не більше %v символів",
+			Many:  "має містити не більше %v символів",
+			Other: "має містити не більше %v символу",
+		},
+		"eq_str_len": {
+			One:   "має містити рівно %v символ",
+			Few:   "має містити рівно %v символи",
+			Many:  "має містити рівно %v символів",
+			Other: "має містити рівно %v символу",
+		},
+		"range_str_len": {
+			One:   "має містити %v..%v символ",
+			Few:   "має містити %v..%v символи",
+			Many:  "має містити %v..%v символів",
+			Other: "має містити %v..%v символу",
+		},
+		"min_set_len": {
+			One:   "має містити щонайменше %v елемент",
+			Few:   "має містити щонайменше %v елементи",
+			Many:  "має містити щонайменше %v елементів",
+			Other: "має містити щонайменше %v елемента",
+		},
+		"max_set_len": {
+			One:   "має містити не більше %v елемента",
+			Few:   "має містити не більше %v елементів",
+			Many:  "має містити не більше %v елементів",
+			Other: "має містити не більше %v елемента",
+		},
+		"eq_set_len": {
+			One:   "має містити рівно %v елемент",
+			Few:   "має містити рівно %v елементи",
+			Many:  "має містити рівно %v елементів",
+			Other: "має містити рівно %v елемента",
+		},
+		"range_set_len": {
+			One:   "має містити %v..%v елемент",
+			Few:   "має містити %v..%v елементи",
+			Many:  "має містити %v..%v елементів",
+			Other: "має містити %v..%v елемента",
+		},
+		"min_bytes": {
+			One:   "має містити щонайменше %v байт",
+			Few:   "має містити щонайменше %v байти",
+			Many:  "має містити щонайменше %v байтів",
+			Other: "має містити щонайменше %v байта",
+		},
+		"max_bytes": {
+			One:   "має містити не більше %v байта",
+			Few:   "має містити не більше %v байтів",
+			Many:  "має містити не більше %v байтів",
+			Other: "має містити не більше %v байта",
+		},
+		"eq_bytes": {
+			One:   "має містити рівно %v байт",
+			Few:   "має містити рівно %v байти",
+			Many:  "має містити рівно %v байтів",
+			Other: "має містити рівно %v байта",
+		},
+		"range_bytes": {
+			One:   "має містити %v..%v байт",
+			Few:   "має містити %v..%v байти",
+			Many:  "має містити %v..%v байтів",
+			Other: "має містити %v..%v байта",
+		},
+		"min":                {Other: "має бути не менше %v"},
+		"max":                {Other: "має бути не більше %v"},
+		"eq":                 {Other: "має дорівнювати %v"},
+		"range":              {Other: "має бути в діапазоні %v..%v"},
+		"in":                 {Other: "має бути одним із %v"},
+		"not_in":             {Other: "не має бути одним із %v"},
+		"contains":           {Other: "має містити %q"},
+		"not_contains":       {Other: "не має містити %q"},
+		"prefix":             {Other: "має починатися з %q"},
+		"suffix":             {Other: "має закінчуватися на %q"},
+		"not_prefix":         {Other: "не має починатися з %q"},
+		"not_suffix":         {Other: "не має закінчуватися на %q"},
+		"not_match":          {Other: "не має відповідати %v"},
+		"not_rule":           {Other: "не має проходити правило %v"},
+		"alpha":              {Other: "має містити лише літери"},
+		"alpha_ascii":        {Other: "має містити лише латинські літери"},
+		"alnum":              {Other: "має містити лише літери та цифри"},
+		"alnum_ascii":        {Other: "має містити лише латинські літери та цифри"},
+		"numeric":            {Other: "має містити лише цифри"},
+		"ascii":              {Other: "має містити лише символи ASCII"},
+		"printable":          {Other: "має містити лише друковані символи"},
+		"printable_ascii":    {Other: "має містити лише друковані символи ASCII"},
+		"lower":              {Other: "не має містити великих літер"},
+		"upper":              {Other: "не має містити малих літер"},
+		"no_space":           {Other: "не має містити пробілів"},
+		"utf8":               {Other: "має бути коректним текстом UTF-8"},
+		"not_valid":          {Other: "має недійсне значення"},
+		"empty":              {Other: "не заповнено"},
+		"unsupported_type":   {Other: "має непідтримуваний для перевірки тип"},
+		"invalid_value":      {Other: "має неприпустиме значення"},
+		"invalid_rule":       {Other: "має недійсне правило"},
+		"invalid_body_value": {Other: "недійсне значення тіла запиту"},
+		"truncated":          {Other: "перевірку зупинено, решту помилок не наведено"},
+		"any_of":             {Other: "%v або %v"},
+		"one_of":             {Other: "має відповідати рівно одному з правил"},
+		"fields_min":         {Other: "має бути заповнено щонайменше %v з полів %v"},
+		"fields_max":         {Other: "може бути заповнено не більше %v з полів %v"},
+		"fields_eq":          {Other: "має бути заповнено рівно %v з полів %v"},
+		"fields_range":       {Other: "має бути заповнено %v..%v з полів %v"},
+		"fields_all_or_none": {Other: "мають бути заповнені всі поля %v або жодного"},
+	},
+}
+
+var catalogs = struct {
+	sync.RWMutex
+	byLang map[string]*Catalog
+}{
+	byLang: map[string]*Catalog{"en": English, "uk": Ukrainian},
+}
+
+// Registers the catalog of the language, or replaces the registered one,
+// including the built-in English and Ukrainian catalogs
+func RegisterCatalog(catalog *Catalog) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+
+	catalogs.byLang[strings.ToLower(catalog.Lang)] = catalog
+}
+
+// Returns the registered catalog that best matches the language preferences,
+// given in the form of the Accept-Language header, e.g. "uk-UA,uk;q=0.9,en;q=0.8".
+// Falls back to the English catalog
+func MatchCatalog(acceptLanguage string) *Catalog {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+
+	for _, lang := range parseAcceptLanguage(acceptLanguage) {
+		if catalog, found := catalogs.byLang[lang]; found {
+			return catalog
+		}
+
+		// the primary language, e.g. "uk" of the "uk-UA"
+		if primary, _, found := strings.Cut(lang, "-"); found {
+			if catalog, found := catalogs.byLang[primary]; found {
+				return catalog
+			}
+		}
+	}
+
+	return catalogs.byLang["en"]
+}
+
+// Returns the language tags ordered by the quality, e.g. "da, en-gb;q=0.8, en;q=0.7"
+func parseAcceptLanguage(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+
+	for _, entry := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+		quality := 1.0
+
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if value, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = value
+			}
+		}
+
+		if tag != "" && tag != "*" && quality > 0 {
+			languages = append(languages, language{tag, quality})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, len(languages))
+
+	for n, lang := range languages {
+		tags[n] = lang.tag
+	}
+
+	return tags
+}
+
+// Renders the message of the catalog, and whether the catalog has it
+func (catalog *Catalog) render(code string, args []any) (string, bool) {
+	message, found := catalog.Messages[code]
+	if !found {
+		return "", false
+	}
+
+	format := message.Other
+
+	if catalog.Plural != nil {
+		if n, ok := pluralNumber(args); ok {
+			switch catalog.Plural(n) {
+			case PluralOne:
+				format = firstNonEmpty(message.One, format)
+			case PluralFew:
+				format = firstNonEmpty(message.Few, format)
+			case PluralMany:
+				format = firstNonEmpty(message.Many, format)
+			}
+		}
+	}
+
+	if format == "" {
+		return "", false
+	}
+
+	return fmt.Sprintf(format, args...), true
+}
+
+// Returns the last argument if it is a non-negative integer
+func pluralNumber(args []any) (uint64, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+
+	num, ok := toNumber(args[len(args)-1])
+
+	switch {
+	case !ok:
+		return 0, false
+
+	case num.kind == numUnsigned:
+		return num.u, true
+
+	case num.kind == numSigned && num.i >= 0:
+		return uint64(num.i), true
+	}
+
+	return 0, false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Renders the message in the language of the catalog. The prefixes of the
+// message, e.g. "item[3] " of the each: modifier, remain as they are, as well
+// as the messages of the custom rules and the ones missing from the catalog
+func (e *ValidationError) Localize(catalog *Catalog) *ValidationError {
+	localized := *e
+
+	if e.Code == "" || catalog == nil {
+		return &localized
+	}
+
+	// the hints of the failed alternatives, e.g. "is empty or is not valid"
+	if len(e.causes) > 0 {
+		localized.causes = make(ValidationErrors, len(e.causes))
+
+		for n, cause := range e.causes {
+			localized.causes[n] = cause.Localize(catalog)
+		}
+
+		format := MsgAnyOf
+		if message, found := catalog.Messages[e.Code]; found && message.Other != "" {
+			format = message.Other
+		}
+
+		localized.Message = joinMessages(format, localized.causes)
+
+		return &localized
+	}
+
+	// the default message, which might be prefixed, e.g. "item[3] must be at least 1"
+	original := messageFormats[e.Code]
+	if len(e.Args) > 0 {
+		original = fmt.Sprintf(original, e.Args...)
+	}
+
+	prefix, found := strings.CutSuffix(e.Message, original)
+	if !found {
+		return &localized
+	}
+
+	if message, found := catalog.render(e.Code, e.Args); found {
+		localized.Message = prefix + message
+	}
+
+	return &localized
+}
+
+// Renders the messages in the language that best matches the preferences,
+// given in the form of the Accept-Language header, e.g.
+//
+//	hints := filter.Errors(data).Localize(r.Header.Get("Accept-Language")).Hints()
+func (errs ValidationErrors) Localize(acceptLanguage string) ValidationErrors {
+	catalog := MatchCatalog(acceptLanguage)
+	localized := make(ValidationErrors, len(errs))
+
+	for n, err := range errs {
+		localized[n] = err.Localize(catalog)
+	}
+
+	return localized
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestCatalog .
+
+func TestCatalog(t *testing.T) {
+	type Article struct {
+		Title  string   `json:"title"`
+		Images []string `json:"images"`
+		Tags   []string `json:"tags"`
+		Age    int      `json:"age"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Catalog`, func() {
+		g.It("keeps the ID and the arguments of the message", func() {
+			errs := Filter{{Field: "Title", Check: Rule{"min", 3}}}.Errors(Article{})
+
+			g.Assert(errs[0].Code).Equal("min_str_len")
+			g.Assert(errs[0].Args).Equal([]any{3})
+			g.Assert(errs[0].Message).Equal(fmt.Sprintf(MsgMinStrLen, 3))
+		})
+
+		g.It("renders the messages in Ukrainian with the plural forms", func() {
+			items := []struct {
+				rule any
+				hint string
+			}{
+				{Rule{"min", 1}, "title має містити щонайменше 1 символ"},
+				{Rule{"min", 3}, "title має містити щонайменше 3 символи"},
+				{Rule{"min", 5}, "title має містити щонайменше 5 символів"},
+				{Rule{"min", 11}, "title має містити щонайменше 11 символів"},
+				{Rule{"min", 21}, "title має містити щонайменше 21 символ"},
+				{Rule{"min", 2.5}, "title має містити щонайменше 2.5 символу"},
+				{Range{2, 4}, "title має містити 2..4 символи"},
+				{NON_ZERO, "title не заповнено"},
+			}
+
+			for _, item := range items {
+				errs := Filter{{Field: "Title", Check: item.rule}}.Errors(Article{})
+
+				g.Assert(errs.Localize("uk").Hints()).Equal([]string{item.hint})
+			}
+		})
+
+		g.It("keeps the prefix of the modifier", func() {
+			filter := Filter{{Field: "Images", Check: Rule{"each:min", 5}}}
+			errs := filter.Errors(Article{Images: []string{"a"}})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{
+				"images item[0] має містити щонайменше 5 символів",
+			})
+		})
+
+		g.It("keeps the default hints", func() {
+			filter := Filter{{Field: "Tags", Check: Rule{"min", 1}}}
+			errs := filter.Errors(Article{})
+
+			g.Assert(errs.Hints()).Equal([]string{"tags " + fmt.Sprintf(MsgMinSetLen, 1)})
+			g.Assert(errs.Localize("en").Hints()).Equal([]string{"tags must contain at least 1 item"})
+			g.Assert(errs.Hints()).Equal([]string{"tags " + fmt.Sprintf(MsgMinSetLen, 1)})
+		})
+
+		g.It("keeps the messages of the custom rules", func() {
+			v := New()
+			v.RegisterRule("test:adult", func(proto, value reflect.Value) string {
+				return "must be an adult"
+			})
+
+			errs := v.Errors(Filter{{Field: "Age", Check: Rule{"test:adult", nil}}}, Article{})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{"age must be an adult"})
+		})
+
+		g.It("falls back to English for the missing messages", func() {
+			RegisterCatalog(&Catalog{
+				Lang:     "test-de",
+				Plural:   PluralEnglish,
+				Messages: map[string]Message{"empty": {Other: "ist leer"}},
+			})
+
+			filter := Filter{
+				{Field: "Title", Check: NON_ZERO},
+				{Field: "Age", Check: Rule{"min", 18}},
+			}
+
+			g.Assert(filter.Errors(Article{}).Localize("test-de").Hints()).Equal([]string{
+				"title ist leer",
+				"age " + fmt.Sprintf(MsgMin, 18),
+			})
+		})
+	})
+
+	g.Describe(`MatchCatalog`, func() {
+		items := []struct {
+			header string
+			lang   string
+		}{
+			{"uk", "uk"},
+			{"uk-UA,uk;q=0.9,en;q=0.8", "uk"},
+			{"UK_ua", "uk"},
+			{"en;q=0.5, uk;q=0.9", "uk"},
+			{"fr-CH, fr;q=0.9, en;q=0.8, uk;q=0.7", "en"},
+			{"fr, uk;q=0", "en"},
+			{"*", "en"},
+			{"", "en"},
+		}
+
+		for _, item := range items {
+			item := item
+
+			g.It(fmt.Sprintf("matches %q for %q", item.lang, item.header), func() {
+				g.Assert(MatchCatalog(item.header).Lang).Equal(item.lang)
+			})
+		}
+	})
+
+	g.Describe(`PluralUkrainian`, func() {
+		forms := map[uint64]PluralForm{
+			0: PluralMany, 1: PluralOne, 2: PluralFew, 4: PluralFew, 5: PluralMany,
+			11: PluralMany, 12: PluralMany, 14: PluralMany, 21: PluralOne, 22: PluralFew,
+			101: PluralOne, 111: PluralMany,
+		}
+
+		g.It("returns the plural forms", func() {
+			for n, form := range forms {
+				g.Assert(PluralUkrainian(n)).Equal(form, n)
+			}
+		})
+	})
+}
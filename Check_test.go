@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestCheck .
+
+func TestCheck(t *testing.T) {
+	type Article struct {
+		Id     uint      `json:"id"`
+		Title  string    `json:"title"`
+		Images []string  `json:"images"`
+		Date   time.Time `json:"date"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Check`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+			}
+
+			err := filter.Check(Article{Id: 1})
+
+			g.Assert(err == nil).IsTrue(err)
+		})
+
+		g.It("failure when given invalid values", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+				{
+					Field: "Title",
+					Check: NON_ZERO,
+				},
+			}
+
+			err := filter.Check(Article{})
+
+			g.Assert(err == nil).IsFalse()
+			g.Assert(err.Error()).Equal("id must be at least 1; title " + MsgEmpty)
+		})
+
+		g.It("unwraps each of the failed filter items", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+				{
+					Field: "Title",
+					Check: NON_ZERO,
+				},
+			}
+
+			err := filter.Check(Article{})
+
+			var errs ValidationErrors
+			g.Assert(errors.As(err, &errs)).IsTrue()
+			g.Assert(len(errs)).Equal(2, errs)
+
+			var target *ValidationError
+			g.Assert(errors.As(err, &target)).IsTrue()
+			g.Assert(target.Path).Equal("Id")
+		})
+	})
+
+	g.Describe(`Sentinel errors`, func() {
+		items := []struct {
+			name   string
+			filter Filter
+			data   Article
+			target error
+		}{
+			{
+				"ErrNotValid",
+				Filter{{Field: "Title", Check: Rule{"match", `^\d+$`}}},
+				Article{Title: "abc"},
+				ErrNotValid,
+			},
+			{
+				"ErrNotValid of the threshold rule",
+				Filter{{Field: "Id", Check: Range{1, 2}}},
+				Article{Id: 3},
+				ErrNotValid,
+			},
+			{
+				"ErrEmpty",
+				Filter{{Field: "Title", Check: NON_ZERO}},
+				Article{},
+				ErrEmpty,
+			},
+			{
+				"ErrInvalidRule",
+				Filter{{Field: "Title", Check: Rule{"mni", 1}}},
+				Article{},
+				ErrInvalidRule,
+			},
+			{
+				"ErrInvalidRule of the unknown field",
+				Filter{{Field: "Unknown", Check: NON_ZERO}},
+				Article{},
+				ErrInvalidRule,
+			},
+			{
+				"ErrUnsupportType",
+				Filter{{Field: "Title", Check: Rule{"year", 2024}}},
+				Article{Title: "abc"},
+				ErrUnsupportType,
+			},
+			{
+				"ErrInvalidBodyVal",
+				Filter{
+					{Field: "Id", Check: Rule{"min", 1}},
+					{Check: Rule{"fields:min", 1}},
+				},
+				Article{},
+				ErrInvalidBodyVal,
+			},
+		}
+
+		for _, item := range items {
+			item := item
+
+			g.It("matches "+item.name, func() {
+				err := item.filter.Check(item.data)
+
+				g.Assert(errors.Is(err, item.target)).IsTrue(err)
+			})
+		}
+
+		g.It("matches ErrInvalidValue", func() {
+			err := filterMin(reflect.ValueOf(1), reflect.ValueOf(nil))
+
+			g.Assert(errors.Is(err, ErrInvalidValue)).IsTrue(err)
+		})
+
+		g.It("tells a misconfigured rule apart from the invalid data", func() {
+			filter := Filter{{Field: "Title", Check: Rule{"match", `^\d+$`}}}
+			err := filter.Check(Article{Title: "abc"})
+
+			g.Assert(errors.Is(err, ErrNotValid)).IsTrue(err)
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsFalse(err)
+		})
+	})
+}
@@ -0,0 +1,1084 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var refTypTime = reflect.TypeOf(time.Time{})
+
+// The filter bound to the type of the structure. The fields are resolved,
+// the rules are verified, and the regular expressions are precompiled
+// once, so the validation does not repeat this work on every call
+type CompiledFilter struct {
+	v      *Validator
+	typ    reflect.Type
+	filter Filter
+	fields []compiledField
+}
+
+type compiledField struct {
+	// whether the field exists, otherwise the item validates the body
+	exist bool
+
+	// the path walks into a map or an interface, so the field
+	// is looked up in the runtime
+	dynamic bool
+
+	// indices of the struct fields on the path
+	steps [][]int
+
+	name string
+}
+
+// The Each sub-filter compiled for the type of the items
+type compiledEach struct {
+	filter Filter
+	fields []compiledField
+}
+
+// The rule with the fast path, which tells whether the value passes without
+// the dispatch by the action. The value that does not pass is checked by
+// the rule itself, so that the error remains the same
+type fastRule struct {
+	rule any
+	pass func(value reflect.Value) bool
+}
+
+// Compiles the filter against the type of the given structure.
+// Verifies that each field exists, that each action is known, and that
+// the prototype of each rule suits the kind of the field. Returns an error
+// wrapping ErrInvalidRule if the filter is misconfigured
+func (filter Filter) Compile(data any) (*CompiledFilter, error) {
+	return std.Compile(filter, data)
+}
+
+// Compiles the filter against the type of the given structure,
+// with the custom rules of the Validator. See Filter.Compile
+func (v *Validator) Compile(filter Filter, data any) (*CompiledFilter, error) {
+	typ := reflect.TypeOf(data)
+
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a structure, given %v", ErrInvalidRule, typ)
+	}
+
+	compiled, fields, err := v.compileFilter(filter, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompiledFilter{v: v, typ: typ, filter: compiled, fields: fields}, nil
+}
+
+// Checks the fields of the structure according to the compiled rules.
+// Returns false or true, respectively
+func (cf *CompiledFilter) IsValid(data any) bool {
+	refValData, ok := cf.data(data)
+	if !ok {
+		return false
+	}
+
+	errs, _ := cf.v.collect(cf.filter, refValData, cf.fields, 1, true)
+	return len(errs) == 0
+}
+
+// Checks the fields of the structure according to the compiled rules.
+// Returns a slice with error hints if at least one field is not valid,
+// otherwise, it will return an empty slice
+func (cf *CompiledFilter) Validate(data any) []string {
+	return cf.Errors(data).Hints()
+}
+
+// Checks the fields of the structure according to the compiled rules.
+// Returns ValidationErrors if at least one field is not valid, otherwise nil
+func (cf *CompiledFilter) Check(data any) error {
+	if errs := cf.Errors(data); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Checks the fields of the structure according to the compiled rules.
+// Returns a slice with the detailed errors if at least one field is not valid,
+// otherwise, it will return an empty slice. The data of another type than
+// the filter was compiled for results in MsgUnsupportType, the nil data
+// results in MsgInvalidValue
+func (cf *CompiledFilter) Errors(data any) ValidationErrors {
+	refValData, ok := cf.data(data)
+	if !ok {
+		if !refValData.IsValid() {
+			return ValidationErrors{newError(MsgInvalidValue)}
+		}
+
+		return ValidationErrors{newError(MsgUnsupportType)}
+	}
+
+	return cf.v.errors(cf.filter, refValData, cf.fields)
+}
+
+// Tells whether the data is of the type the filter was compiled for
+func (cf *CompiledFilter) data(data any) (reflect.Value, bool) {
+	refValData := reflect.Indirect(reflect.ValueOf(data))
+
+	return refValData, refValData.IsValid() && refValData.Type() == cf.typ
+}
+
+func (field *compiledField) lookup(v *Validator, data reflect.Value, path string) (reflect.Value, string, bool) {
+	if field.dynamic {
+		return v.lookupField(data, path)
+	}
+
+	if !field.exist {
+		return refNil, "", false
+	}
+
+	for _, index := range field.steps {
+		for data.Kind() == reflect.Pointer {
+			if data.IsNil() {
+				return refNil, field.name, true
+			}
+
+			data = data.Elem()
+		}
+
+		var err error
+
+		// an embedded pointer on the way might be nil
+		if data, err = data.FieldByIndexErr(index); err != nil {
+			return refNil, field.name, true
+		}
+	}
+
+	return data, field.name, true
+}
+
+// Compiles the rules of the filter. The nil type stands for the data
+// that is known only in the runtime (e.g. a map), so the fields are
+// looked up dynamically and the kinds of the fields are not verified
+func (v *Validator) compileFilter(filter Filter, typ reflect.Type) (Filter, []compiledField, error) {
+	compiled := make(Filter, len(filter))
+	fields := make([]compiledField, len(filter))
+
+	for n, item := range filter {
+		var (
+			fieldType reflect.Type
+			err       error
+		)
+
+		if typ == nil {
+			fields[n].dynamic = true
+		} else {
+			fields[n], fieldType, err = v.compileField(typ, item.Field)
+		}
+
+		if err == nil {
+			if item.Field != "" && (fields[n].exist || fields[n].dynamic) {
+				item.Check, err = v.compileRules(item.Check, typ, fieldType)
+				item.Check = fastRules(item.Check, fieldType)
+			} else {
+				item.Check, err = v.compileBodyRule(item.Check, typ)
+			}
+		}
+
+		if err != nil {
+			if item.Field == "" {
+				return nil, nil, fmt.Errorf("filter item %d %w: %v", n, ErrInvalidRule, err)
+			}
+
+			return nil, nil, fmt.Errorf("field %s %w: %v", item.Field, ErrInvalidRule, err)
+		}
+
+		compiled[n] = item
+	}
+
+	return compiled, fields, nil
+}
+
+// Resolves the dotted path of the field within the type of the structure.
+// Returns the resolved field and its type, which is nil if the path walks
+// into a map or an interface
+func (v *Validator) compileField(typ reflect.Type, path string) (compiledField, reflect.Type, error) {
+	field := compiledField{}
+
+	if path == "" {
+		return field, nil, nil
+	}
+
+	field.exist = true
+	name := path
+
+	for name != "" {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Map || typ.Kind() == reflect.Interface {
+			field.dynamic = true
+			return field, nil, nil
+		}
+
+		if typ.Kind() != reflect.Struct {
+			return field, nil, fmt.Errorf("%v has no fields", typ)
+		}
+
+		var rest string
+
+		name, rest, _ = strings.Cut(name, ".")
+
+		structField, exist := typ.FieldByName(name)
+		if !exist {
+			return field, nil, fmt.Errorf("%v has no field %s", typ, name)
+		}
+
+		field.steps = append(field.steps, structField.Index)
+		field.name = joinName(field.name, v.fieldName(structField))
+		typ = structField.Type
+		name = rest
+	}
+
+	return field, typ, nil
+}
+
+// Verifies the rules against the type of the field, and returns
+// the rules with the regular expressions precompiled. The references
+// are resolved against the root type of the data
+func (v *Validator) compileRules(rules any, root, typ reflect.Type) (any, error) {
+	switch rules := rules.(type) {
+	case Group:
+		group := make(Group, len(rules))
+
+		for n, item := range rules {
+			compiled, err := v.compileRules(item, root, typ)
+			if err != nil {
+				return nil, err
+			}
+
+			group[n] = compiled
+		}
+
+		return group, nil
+
+	case AllOf:
+		group, err := v.compileRules(Group(rules), root, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return AllOf(group.(Group)), nil
+
+	case AnyOf:
+		if len(rules) == 0 {
+			return nil, fmt.Errorf("missing rule")
+		}
+
+		group, err := v.compileRules(Group(rules), root, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return AnyOf(group.(Group)), nil
+
+	case OneOf:
+		if len(rules) == 0 {
+			return nil, fmt.Errorf("missing rule")
+		}
+
+		group, err := v.compileRules(Group(rules), root, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return OneOf(group.(Group)), nil
+
+	case Not:
+		compiled, err := v.compileRules(rules[0], root, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{compiled}, nil
+
+	case Each:
+		return v.compileEach(rules, typ)
+
+	case Range:
+		if hasRef(rules[:]) {
+			return rules, v.compileRefAction("range", rules[:], root, typ)
+		}
+
+		if _, err := v.compileAction("range", rules, typ); err != nil {
+			return nil, err
+		}
+
+		return rules, nil
+
+	case Rule:
+		action, ok := rules[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("the action must be a string, given %T", rules[0])
+		}
+
+		if hasRef(rules[1:]) {
+			return rules, v.compileRefAction(action, rules[1:], root, typ)
+		}
+
+		proto, err := v.compileAction(action, rules[1], typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return Rule{action, proto}, nil
+
+	case string:
+		if rules == NON_ZERO {
+			return rules, nil
+		}
+
+		return nil, fmt.Errorf("unknown rule %q", rules)
+
+	case nil:
+		return nil, fmt.Errorf("missing rule")
+	}
+
+	if ptr := reflect.ValueOf(rules); ptr.Kind() == reflect.Pointer && !ptr.IsNil() {
+		return v.compileRules(ptr.Elem().Interface(), root, typ)
+	}
+
+	return nil, fmt.Errorf("unsupported rule %T", rules)
+}
+
+func (v *Validator) compileEach(filter Each, typ reflect.Type) (any, error) {
+	var elem reflect.Type
+
+	if typ != nil {
+		switch typ.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			elem = typ.Elem()
+
+		default:
+			return nil, fmt.Errorf("each sub-filter is not applicable to %v", typ)
+		}
+
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+
+		switch elem.Kind() {
+		case reflect.Struct:
+		case reflect.Map, reflect.Interface:
+			// the items are known only in the runtime
+			elem = nil
+
+		default:
+			return nil, fmt.Errorf("each sub-filter is not applicable to the items of %v", typ)
+		}
+	}
+
+	compiled, fields, err := v.compileFilter(Filter(filter), elem)
+	if err != nil {
+		return nil, err
+	}
+
+	return compiledEach{filter: compiled, fields: fields}, nil
+}
+
+// Attaches the fast paths to the rules of the field. The rules nested
+// in AnyOf, OneOf and Not remain as they are, since their errors hold them
+func fastRules(rules any, typ reflect.Type) any {
+	switch rules := rules.(type) {
+	case Group:
+		group := make(Group, len(rules))
+
+		for n, item := range rules {
+			group[n] = fastRules(item, typ)
+		}
+
+		return group
+
+	case AllOf:
+		return AllOf(fastRules(Group(rules), typ).(Group))
+
+	case Range:
+		if pass := fastPath("range", rules, typ); pass != nil {
+			return fastRule{rule: rules, pass: pass}
+		}
+
+	case Rule:
+		action, _ := rules[0].(string)
+
+		if pass := fastPath(action, rules[1], typ); pass != nil {
+			return fastRule{rule: rules, pass: pass}
+		}
+
+	case string:
+		if pass := fastPath(rules, nil, typ); pass != nil {
+			return fastRule{rule: rules, pass: pass}
+		}
+	}
+
+	return rules
+}
+
+// Returns the check of the value that passes the rule, or nil if the rule
+// has no fast path for the type, e.g. the custom rules or the references
+func fastPath(action string, proto any, typ reflect.Type) func(value reflect.Value) bool {
+	if typ == nil || typ.Kind() == reflect.Interface {
+		return nil
+	}
+
+	switch action {
+	case NON_ZERO:
+		return func(value reflect.Value) bool {
+			return value.IsValid() && !value.IsZero()
+		}
+
+	case "min", "max", "eq":
+		num, ok := toNumber(proto)
+		if !ok {
+			return nil
+		}
+
+		return func(value reflect.Value) bool {
+			result, ok := measure(value, num)
+			return ok && (action == "min" && result >= 0 || action == "max" && result <= 0 || action == "eq" && result == 0)
+		}
+
+	case "range":
+		protos := reflect.ValueOf(proto)
+		if (protos.Kind() != reflect.Array && protos.Kind() != reflect.Slice) || protos.Len() != 2 {
+			return nil
+		}
+
+		numMin, okMin := toNumber(protos.Index(0).Interface())
+		numMax, okMax := toNumber(protos.Index(1).Interface())
+		if !okMin || !okMax {
+			return nil
+		}
+
+		return func(value reflect.Value) bool {
+			resultMin, okMin := measure(value, numMin)
+			resultMax, okMax := measure(value, numMax)
+			return okMin && okMax && resultMin >= 0 && resultMax <= 0
+		}
+
+	case "date:min", "date:max", "date:eq", "time:min", "time:max", "time:eq":
+		return fastTimePath(action, proto, typ)
+
+	case "match":
+		re, ok := proto.(*regexp.Regexp)
+		if !ok || re == nil || typ.Kind() != reflect.String {
+			return nil
+		}
+
+		return func(value reflect.Value) bool {
+			return value.Kind() == reflect.String && re.MatchString(value.String())
+		}
+	}
+
+	if rest, found := strings.CutPrefix(action, "each:"); found {
+		switch typ.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+		default:
+			return nil
+		}
+
+		pass := fastPath(rest, proto, typ.Elem())
+		if pass == nil {
+			return nil
+		}
+
+		return func(value reflect.Value) bool {
+			switch value.Kind() {
+			case reflect.Array, reflect.Slice:
+				for n := 0; n < value.Len(); n++ {
+					if !pass(value.Index(n)) {
+						return false
+					}
+				}
+
+				return true
+
+			case reflect.Map:
+				iter := value.MapRange()
+
+				for iter.Next() {
+					if !pass(iter.Value()) {
+						return false
+					}
+				}
+
+				return true
+			}
+
+			return false
+		}
+	}
+
+	return nil
+}
+
+// Returns the check of the time that passes the date: or the time: rule,
+// which compare the Unix time in seconds or in nanoseconds, respectively
+func fastTimePath(action string, proto any, typ reflect.Type) func(value reflect.Value) bool {
+	if typ != refTypTime {
+		return nil
+	}
+
+	var (
+		tmProto int64
+		err     error
+	)
+
+	modifier, action, _ := strings.Cut(action, ":")
+	seconds := modifier == "date"
+
+	switch proto := proto.(type) {
+	case int64:
+		tmProto = proto
+
+	case time.Time:
+		tmProto = proto.UnixNano()
+
+		if seconds {
+			tmProto = proto.Unix()
+		}
+
+	case string:
+		if seconds {
+			var tm time.Time
+			tm, err = time.Parse(time.RFC3339, proto)
+			tmProto = tm.Unix()
+		} else {
+			tmProto, err = strconv.ParseInt(proto, 10, 64)
+		}
+
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return nil
+	}
+
+	return func(value reflect.Value) bool {
+		if !value.IsValid() || value.Type() != refTypTime {
+			return false
+		}
+
+		tm := value.Interface().(time.Time)
+		tmValue := tm.UnixNano()
+
+		if seconds {
+			tmValue = tm.Unix()
+		}
+
+		return action == "min" && tmValue >= tmProto || action == "max" && tmValue <= tmProto || action == "eq" && tmValue == tmProto
+	}
+}
+
+// Compares the value with the prototype the way the min, max, eq and range
+// rules do: the number of characters of the string, the length of the
+// collection, or the number itself
+func measure(value reflect.Value, proto number) (int, bool) {
+	var num number
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num = numberOf(value.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num = numberOf(value.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		num = numberOf(value.Float())
+
+	case reflect.String:
+		num = numberOf(utf8.RuneCountInString(value.String()))
+
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		num = numberOf(value.Len())
+
+	default:
+		return 0, false
+	}
+
+	return num.compare(proto)
+}
+
+// Verifies the "fields:" rule that validates the body
+func (v *Validator) compileBodyRule(rules any, typ reflect.Type) (any, error) {
+	switch set := rules.(type) {
+	case *FieldSet:
+		if set != nil {
+			return v.compileBodyRule(*set, typ)
+		}
+
+	case FieldSet:
+		if !set.valid() {
+			return nil, fmt.Errorf("invalid field set %v", set.Fields)
+		}
+
+		// the fields of the maps are looked up at runtime
+		for _, path := range set.Fields {
+			if typ == nil {
+				break
+			}
+
+			if _, _, err := v.compileField(typ, path); err != nil {
+				return nil, err
+			}
+		}
+
+		return set, nil
+	}
+
+	if rule, ok := rules.(*Rule); ok && rule != nil {
+		return v.compileBodyRule(*rule, typ)
+	}
+
+	rule, ok := rules.(Rule)
+	if !ok {
+		return nil, fmt.Errorf("expected the fields rule, given %T", rules)
+	}
+
+	action, _ := rule[0].(string)
+
+	// the rule applies to the number of the valid fields, e.g. "fields:range"
+	if rest, found := strings.CutPrefix(action, "fields:"); found && !hasRef(rule[1:]) {
+		proto, err := v.compileAction(rest, rule[1], reflect.TypeOf(0))
+		if err != nil {
+			return nil, err
+		}
+
+		return Rule{action, proto}, nil
+	}
+
+	return nil, fmt.Errorf("unknown action %q of the body rule", action)
+}
+
+// Verifies the action of the rule that refers to the other fields, e.g.
+// Rule{"date:min", Ref("StartDate")}. The references are resolved against
+// the root type, which is nil if unknown, so the kinds of the referenced
+// fields are verified as the prototypes are
+func (v *Validator) compileRefAction(action string, protos []any, root, typ reflect.Type) error {
+	if _, found := v.rule(action); found {
+		return nil
+	}
+
+	if !isBuiltinAction(action) {
+		name, _, found := strings.Cut(action, ":")
+		if _, exist := v.modifier(name); !found || !exist {
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+
+	for _, proto := range protos {
+		ref, ok := proto.(Ref)
+		if !ok {
+			continue
+		}
+
+		var refTyp reflect.Type
+
+		if root != nil {
+			field, fieldType, err := v.compileField(root, string(ref))
+			if err == nil && !field.exist {
+				err = fmt.Errorf("empty path")
+			}
+
+			if err != nil {
+				return fmt.Errorf("%q has invalid reference: %v", action, err)
+			}
+
+			refTyp = fieldType
+		}
+
+		if err := v.checkRef(action, ref, typ, refTyp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Verifies the kinds of the field and of the referenced field against
+// the action, the same way as compileAction verifies the prototype
+func (v *Validator) checkRef(action string, ref Ref, typ, refTyp reflect.Type) error {
+	for {
+		name, rest, found := strings.Cut(action, ":")
+		if !found {
+			break
+		}
+
+		if _, found := v.modifier(name); found {
+			action, typ = rest, modifiedType(name, typ)
+			continue
+		}
+
+		// the items are verified in the runtime
+		if name == "each" {
+			action, typ = rest, nil
+			continue
+		}
+
+		break
+	}
+
+	refTyp = pointee(refTyp)
+
+	var suitsRef, suits func(reflect.Type) bool
+
+	switch action {
+	case "min", "max", "range":
+		suitsRef, suits = isNumeric, isMeasurable
+
+	case "eq":
+		suitsRef, suits = isNumeric, isMeasurable
+
+		// the strings are compared by their values
+		if typ == nil || typ.Kind() == reflect.Interface || isString(typ) {
+			suitsRef = isScalar
+		}
+
+	case "in", "notIn":
+		suitsRef, suits = isScalarCollection, isScalar
+		typ = pointee(typ)
+
+	case "contains", "notContains", "prefix", "suffix",
+		"icontains", "inotContains", "iprefix", "isuffix", "match":
+		suitsRef, suits = isString, isString
+		typ = pointee(typ)
+
+	case "year":
+		suitsRef, suits = isNumeric, isTime
+
+	case "date:min", "date:max", "date:eq", "time:min", "time:max", "time:eq":
+		suitsRef, suits = isTimePrototype, isTime
+
+	default:
+		return nil
+	}
+
+	if refTyp != nil && refTyp.Kind() != reflect.Interface && !suitsRef(refTyp) {
+		return fmt.Errorf("%q cannot refer to %s of %v", action, string(ref), refTyp)
+	}
+
+	return checkKind(action, typ, suits)
+}
+
+// Verifies the action and its prototype against the type of the field,
+// which is nil if unknown. Returns the prototype to use in the runtime
+func (v *Validator) compileAction(action string, proto any, typ reflect.Type) (any, error) {
+	if action == NON_ZERO {
+		return proto, nil
+	}
+
+	// the custom rules verify the prototype and the kind themselves
+	if _, found := v.rule(action); found {
+		return proto, nil
+	}
+
+	if name, rest, found := strings.Cut(action, ":"); found {
+		if _, found := v.modifier(name); found {
+			// the length of the number is rather a typo of the rule
+			if name == "len" || name == "bytes" {
+				if err := checkKind(action, typ, hasLen); err != nil {
+					return nil, err
+				}
+			}
+
+			return v.compileAction(rest, proto, modifiedType(name, typ))
+		}
+	}
+
+	if action == "utf8" {
+		if proto != nil {
+			return nil, fmt.Errorf("%q expects no prototype, given %v", action, proto)
+		}
+
+		return nil, checkKind(action, pointee(typ), isString)
+	}
+
+	// the character classes take no prototype, or the ASCII restriction
+	if class, found := charClasses[action]; found {
+		if proto != nil && (proto != ASCII || class.allowsASCII == nil) {
+			return nil, fmt.Errorf("%q expects no prototype or ASCII, given %v", action, proto)
+		}
+
+		return proto, checkKind(action, pointee(typ), isString)
+	}
+
+	// the modifiers verify the prototype with the rule they apply
+	if proto == nil && !strings.HasPrefix(action, "each:") {
+		return nil, fmt.Errorf("%q has no prototype", action)
+	}
+
+	switch action {
+	case "min", "max", "eq":
+		if !isNumber(proto) {
+			return nil, fmt.Errorf("%q expects a numeric prototype, given %T", action, proto)
+		}
+
+		return proto, checkKind(action, typ, isMeasurable)
+
+	case "range":
+		refProto := reflect.ValueOf(proto)
+
+		switch refProto.Kind() {
+		case reflect.Array, reflect.Slice:
+			if refProto.Len() == 2 && isNumber(refProto.Index(0).Interface()) &&
+				isNumber(refProto.Index(1).Interface()) {
+				return proto, checkKind(action, typ, isMeasurable)
+			}
+		}
+
+		return nil, fmt.Errorf("%q expects a pair of numbers, given %v", action, proto)
+
+	case "in", "notIn":
+		refProto := reflect.ValueOf(proto)
+
+		switch refProto.Kind() {
+		case reflect.Array, reflect.Slice:
+			if refProto.Len() > 0 && isScalarList(refProto) {
+				return proto, checkKind(action, pointee(typ), isScalar)
+			}
+		}
+
+		return nil, fmt.Errorf("%q expects a list of numbers or strings, given %v", action, proto)
+
+	case "contains", "notContains", "prefix", "suffix",
+		"icontains", "inotContains", "iprefix", "isuffix":
+		if substr, ok := proto.(string); !ok || substr == "" {
+			return nil, fmt.Errorf("%q expects a non-empty string, given %v", action, proto)
+		}
+
+		return proto, checkKind(action, pointee(typ), isString)
+
+	case "match":
+		if re, ok := proto.(*regexp.Regexp); ok && re != nil {
+			return re, checkKind(action, pointee(typ), isString)
+		}
+
+		pattern, ok := proto.(string)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%q expects a regular expression, given %v", action, proto)
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%q has invalid pattern: %v", action, err)
+		}
+
+		return re, checkKind(action, pointee(typ), isString)
+
+	case "year":
+		if !isNumber(proto) {
+			return nil, fmt.Errorf("%q expects a numeric prototype, given %T", action, proto)
+		}
+
+		return proto, checkKind(action, typ, isTime)
+
+	case "date:min", "date:max", "date:eq", "time:min", "time:max", "time:eq":
+		switch proto := proto.(type) {
+		case int64, time.Time:
+
+		case string:
+			var err error
+
+			if action[:5] == "date:" {
+				_, err = time.Parse(time.RFC3339, proto)
+			} else {
+				_, err = strconv.ParseInt(proto, 10, 64)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("%q has invalid prototype: %v", action, err)
+			}
+
+		default:
+			return nil, fmt.Errorf("%q expects int64, string, or time.Time prototype, given %T", action, proto)
+		}
+
+		return proto, checkKind(action, typ, isTime)
+
+	default:
+		if !strings.HasPrefix(action, "each:") {
+			return nil, fmt.Errorf("unknown action %q", action)
+		}
+
+		var elem reflect.Type
+
+		if typ != nil && typ.Kind() != reflect.Interface {
+			if err := checkKind(action, typ, isCollection); err != nil {
+				return nil, err
+			}
+
+			elem = typ.Elem()
+
+			if elem.Kind() == reflect.Interface {
+				elem = nil
+			}
+		}
+
+		return v.compileAction(action[5:], proto, elem)
+	}
+}
+
+// Returns the type of the value that the modifier passes to the rule,
+// which is nil if unknown, e.g. for the custom modifiers
+func modifiedType(modifier string, typ reflect.Type) reflect.Type {
+	if typ == nil || typ.Kind() == reflect.Interface {
+		return nil
+	}
+
+	switch modifier {
+	case "trim":
+		return typ
+
+	case "abs":
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return reflect.TypeOf(uint64(0))
+		}
+
+		return typ
+
+	case "keys":
+		if typ.Kind() == reflect.Map {
+			return typ.Key()
+		}
+
+	case "len", "bytes":
+		return reflect.TypeOf(0)
+	}
+
+	return nil
+}
+
+// The type the pointers point to, for the rules that dereference the value,
+// e.g. "match" of the *string
+func pointee(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+func checkKind(action string, typ reflect.Type, suits func(reflect.Type) bool) error {
+	if typ != nil && typ.Kind() != reflect.Interface && !suits(typ) {
+		return fmt.Errorf("%q is not applicable to %v", action, typ)
+	}
+
+	return nil
+}
+
+// The NaN is not a number to compare with
+func isNumber(value any) bool {
+	refValue := reflect.ValueOf(value)
+
+	switch refValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+
+	case reflect.Float32, reflect.Float64:
+		return !math.IsNaN(refValue.Float())
+	}
+
+	return false
+}
+
+func isMeasurable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Array, reflect.Chan, reflect.Map, reflect.Slice,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func hasLen(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		return true
+	}
+
+	return false
+}
+
+func isScalar(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func isScalarList(list reflect.Value) bool {
+	for n := 0; n < list.Len(); n++ {
+		item := list.Index(n)
+		if item.Kind() == reflect.Interface {
+			item = item.Elem()
+		}
+
+		if item.Kind() != reflect.String && !(item.IsValid() && isNumber(item.Interface())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNumeric(typ reflect.Type) bool {
+	return isScalar(typ) && typ.Kind() != reflect.String
+}
+
+func isScalarCollection(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Array, reflect.Slice:
+		return typ.Elem().Kind() == reflect.Interface || isScalar(typ.Elem())
+	}
+
+	return false
+}
+
+// The prototypes of the date: and time: rules
+func isTimePrototype(typ reflect.Type) bool {
+	return isTime(typ) || typ == reflect.TypeOf(int64(0)) || typ == reflect.TypeOf("")
+}
+
+func isString(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String
+}
+
+func isTime(typ reflect.Type) bool {
+	return typ == refTypTime
+}
+
+func isCollection(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return true
+	}
+
+	return false
+}
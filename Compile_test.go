@@ -0,0 +1,473 @@
+package validator
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestCompile .
+
+func TestCompile(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+
+	type LineItem struct {
+		Sku string `json:"sku"`
+	}
+
+	type Article struct {
+		Id       uint              `json:"id"`
+		Title    string            `json:"title"`
+		Phone    string            `json:"phone"`
+		Images   []string          `json:"images"`
+		Date     time.Time         `json:"date"`
+		Address  Address           `json:"address"`
+		Billing  *Address          `json:"billing"`
+		Items    []LineItem        `json:"items"`
+		Meta     map[string]any    `json:"meta"`
+		Options  map[string]string `json:"options"`
+		Anything any               `json:"anything"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Compile`, func() {
+		filter := Filter{
+			{
+				Field: "Id",
+				Check: Rule{"min", 1},
+			},
+			{
+				Field: "Title",
+				Check: Group{NON_ZERO, Range{3, 64}},
+			},
+			{
+				Field:    "Phone",
+				Check:    Rule{"match", `^\+38\d{10}$`},
+				Optional: true,
+			},
+			{
+				Field: "Images",
+				Check: Rule{"each:match", `^https://`},
+			},
+			{
+				Field: "Date",
+				Check: Rule{"date:min", "2024-01-01T00:00:00Z"},
+			},
+			{
+				Field: "Address.City",
+				Check: Rule{"min", 2},
+			},
+			{
+				Field: "Billing.City",
+				Check: Rule{"min", 2},
+			},
+			{
+				Field: "Items",
+				Check: Each{{Field: "Sku", Check: Rule{"match", `^[A-Z]{3}$`}}},
+			},
+			{
+				Field: "Meta.author",
+				Check: NON_ZERO,
+			},
+			{
+				Check: Rule{"fields:min", 1},
+			},
+		}
+
+		valid := Article{
+			Id:      1,
+			Title:   "Yellow submarine",
+			Images:  []string{"https://img.it/1.jpg"},
+			Date:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Address: Address{City: "Kyiv"},
+			Billing: &Address{City: "Lviv"},
+			Items:   []LineItem{{Sku: "ABC"}},
+			Meta:    map[string]any{"author": "John"},
+		}
+
+		g.It("success when given valid values", func() {
+			compiled, err := filter.Compile(Article{})
+			g.Assert(err).IsNil()
+
+			hints := compiled.Validate(valid)
+
+			g.Assert(len(hints)).Equal(0, hints)
+			g.Assert(compiled.IsValid(&valid)).IsTrue()
+			g.Assert(compiled.Check(valid) == nil).IsTrue()
+		})
+
+		g.It("returns the same hints as the filter", func() {
+			compiled, err := filter.Compile(&Article{})
+			g.Assert(err).IsNil()
+
+			items := []Article{
+				{},
+				{Phone: "0001234567", Images: []string{"http://img.it/1.jpg"}},
+				{Billing: &Address{}, Items: []LineItem{{Sku: "abc"}}},
+				{Meta: map[string]any{"author": ""}},
+			}
+
+			for _, article := range items {
+				g.Assert(compiled.Validate(article)).Equal(filter.Validate(article))
+			}
+		})
+
+		g.It("takes the fast paths with the same result as the filter", func() {
+			type Sample struct {
+				Age   int8             `json:"age"`
+				Score float64          `json:"score"`
+				Size  uint64           `json:"size"`
+				Name  string           `json:"name"`
+				Tags  []string         `json:"tags"`
+				Ranks map[string]uint8 `json:"ranks"`
+				Start time.Time        `json:"start"`
+				End   time.Time        `json:"end"`
+			}
+
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			filter := Filter{
+				{Field: "Age", Check: Group{Rule{"min", -1}, Rule{"max", 100.5}}},
+				{Field: "Score", Check: AllOf{Rule{"min", 0}, Rule{"eq", 0.5}}},
+				{Field: "Size", Check: Range{uint64(1), uint64(math.MaxUint64)}},
+				{Field: "Name", Check: Group{NON_ZERO, Rule{"range", []uint8{2, 4}}, Rule{"match", `^\pL+$`}}},
+				{Field: "Tags", Check: Group{Rule{"max", 2}, Rule{"each:min", 2}}},
+				{Field: "Ranks", Check: Rule{"each:range", Range{1, 5}}},
+				{Field: "Start", Check: Group{Rule{"date:min", "2024-01-01T00:00:00Z"}, Rule{"time:max", start.UnixNano()}}},
+				{Field: "End", Check: AllOf{Rule{"date:eq", start.Unix()}, Rule{"time:min", start}}},
+			}
+
+			compiled, err := filter.Compile(Sample{})
+			g.Assert(err).IsNil()
+
+			items := []Sample{
+				{},
+				{Age: -1, Score: 0.5, Size: math.MaxUint64, Name: "Оля", Tags: []string{"go"}, Ranks: map[string]uint8{"a": 5}, Start: start, End: start},
+				{Age: -2, Score: math.NaN(), Name: "Olena", Tags: []string{"go", "c", "js"}, Ranks: map[string]uint8{"a": 6}, Start: start.Add(time.Second), End: start.Add(time.Millisecond)},
+				{Age: 101, Score: -1, Name: "O1", Tags: []string{"c"}, Ranks: map[string]uint8{"a": 0}, Start: start.Add(-time.Second), End: start.Add(-time.Millisecond)},
+			}
+
+			for _, sample := range items {
+				g.Assert(compiled.Validate(sample)).Equal(filter.Validate(sample), sample)
+			}
+		})
+
+		g.It("failure when given another type of data", func() {
+			compiled, err := filter.Compile(Article{})
+			g.Assert(err).IsNil()
+
+			err = compiled.Check(Address{})
+
+			g.Assert(errors.Is(err, ErrUnsupportType)).IsTrue(err)
+			g.Assert(compiled.IsValid(nil)).IsFalse()
+			g.Assert(errors.Is(compiled.Check((*Article)(nil)), ErrInvalidValue)).IsTrue()
+		})
+
+		g.It("accepts the precompiled regular expressions", func() {
+			compiled, err := Filter{{
+				Field: "Title",
+				Check: Rule{"match", regexp.MustCompile(`^\d+$`)},
+			}}.Compile(Article{})
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Article{Title: "abc"})).Equal([]string{
+				"title " + MsgNotValid,
+			})
+		})
+
+		g.It("failure when given not a structure", func() {
+			_, err := filter.Compile(map[string]any{})
+
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("failure when given a misconfigured filter", func() {
+			items := []struct {
+				item    FilterItem
+				message string
+			}{
+				{
+					FilterItem{Field: "Id", Check: Rule{"mni", 1}},
+					`field Id has invalid rule: unknown action "mni"`,
+				},
+				{
+					FilterItem{Field: "Unknown", Check: NON_ZERO},
+					`field Unknown has invalid rule: validator.Article has no field Unknown`,
+				},
+				{
+					FilterItem{Field: "Address.Street", Check: NON_ZERO},
+					`field Address.Street has invalid rule: validator.Address has no field Street`,
+				},
+				{
+					FilterItem{Field: "Id.Value", Check: NON_ZERO},
+					`field Id.Value has invalid rule: uint has no fields`,
+				},
+				{
+					FilterItem{Field: "Id", Check: nil},
+					`field Id has invalid rule: missing rule`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"in", []any{}}},
+					`field Id has invalid rule: "in" expects a list of numbers or strings, given []`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"notIn", []any{1, true}}},
+					`field Id has invalid rule: "notIn" expects a list of numbers or strings, given [1 true]`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"in", []int{1}}},
+					`field Date has invalid rule: "in" is not applicable to time.Time`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"prefix", ""}},
+					`field Title has invalid rule: "prefix" expects a non-empty string, given `,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"icontains", "1"}},
+					`field Id has invalid rule: "icontains" is not applicable to uint`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"lower", ASCII}},
+					`field Title has invalid rule: "lower" expects no prototype or ASCII, given ascii`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"numeric", nil}},
+					`field Id has invalid rule: "numeric" is not applicable to uint`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"bytes:max", 8}},
+					`field Id has invalid rule: "bytes:max" is not applicable to uint`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"len:max", 8}},
+					`field Date has invalid rule: "len:max" is not applicable to time.Time`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"bytes:max", "8"}},
+					`field Title has invalid rule: "max" expects a numeric prototype, given string`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"utf8", true}},
+					`field Title has invalid rule: "utf8" expects no prototype, given true`,
+				},
+				{
+					FilterItem{Check: AtLeastOne("Id", "Unknown")},
+					`filter item 0 has invalid rule: validator.Article has no field Unknown`,
+				},
+				{
+					FilterItem{Check: FieldSet{}},
+					`filter item 0 has invalid rule: invalid field set []`,
+				},
+				{
+					FilterItem{Check: FieldSet{Fields: []string{"Id", "Title"}}},
+					`filter item 0 has invalid rule: invalid field set [Id Title]`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"mni", Ref("Id")}},
+					`field Id has invalid rule: unknown action "mni"`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"min", Ref("Nope")}},
+					`field Id has invalid rule: "min" has invalid reference: validator.Article has no field Nope`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"min", Ref("Title")}},
+					`field Id has invalid rule: "min" cannot refer to Title of string`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Range{1, Ref("Phone")}},
+					`field Id has invalid rule: "range" cannot refer to Phone of string`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"date:min", Ref("Id")}},
+					`field Date has invalid rule: "date:min" cannot refer to Id of uint`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"min", Ref("Id")}},
+					`field Date has invalid rule: "min" is not applicable to time.Time`,
+				},
+				{
+					FilterItem{Field: "Id", Check: AnyOf{}},
+					`field Id has invalid rule: missing rule`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Not{Rule{"mni", 1}}},
+					`field Id has invalid rule: unknown action "mni"`,
+				},
+				{
+					FilterItem{Field: "Id", Check: "UNDEFINED_RULE"},
+					`field Id has invalid rule: unknown rule "UNDEFINED_RULE"`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{1, 1}},
+					`field Id has invalid rule: the action must be a string, given int`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"min", nil}},
+					`field Id has invalid rule: "min" has no prototype`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"min", "1"}},
+					`field Id has invalid rule: "min" expects a numeric prototype, given string`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"max", math.NaN()}},
+					`field Id has invalid rule: "max" expects a numeric prototype, given float64`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"min", 1}},
+					`field Date has invalid rule: "min" is not applicable to time.Time`,
+				},
+				{
+					FilterItem{Field: "Id", Check: Range{1, "2"}},
+					`field Id has invalid rule: "range" expects a pair of numbers, given [1 2]`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"match", `^(abc$`}},
+					"field Title has invalid rule: \"match\" has invalid pattern: error parsing regexp: missing closing ): `^(abc$`",
+				},
+				{
+					FilterItem{Field: "Id", Check: Rule{"match", `^\d+$`}},
+					`field Id has invalid rule: "match" is not applicable to uint`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"year", 2024}},
+					`field Title has invalid rule: "year" is not applicable to string`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"date:min", "2024-01-01"}},
+					`field Date has invalid rule: "date:min" has invalid prototype: parsing time "2024-01-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"time:min", "now"}},
+					`field Date has invalid rule: "time:min" has invalid prototype: strconv.ParseInt: parsing "now": invalid syntax`,
+				},
+				{
+					FilterItem{Field: "Date", Check: Rule{"time:min", 1}},
+					`field Date has invalid rule: "time:min" expects int64, string, or time.Time prototype, given int`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Rule{"each:min", 1}},
+					`field Title has invalid rule: "each:min" is not applicable to string`,
+				},
+				{
+					FilterItem{Field: "Images", Check: Rule{"each:mni", 1}},
+					`field Images has invalid rule: unknown action "mni"`,
+				},
+				{
+					FilterItem{Field: "Images", Check: Rule{"each:year", 1}},
+					`field Images has invalid rule: "year" is not applicable to string`,
+				},
+				{
+					FilterItem{Field: "Title", Check: Each{}},
+					`field Title has invalid rule: each sub-filter is not applicable to string`,
+				},
+				{
+					FilterItem{Field: "Images", Check: Each{}},
+					`field Images has invalid rule: each sub-filter is not applicable to the items of []string`,
+				},
+				{
+					FilterItem{Field: "Items", Check: Each{{Field: "Sku", Check: Rule{"mni", 1}}}},
+					`field Items has invalid rule: field Sku has invalid rule: unknown action "mni"`,
+				},
+				{
+					FilterItem{Check: Rule{"min", 1}},
+					`filter item 0 has invalid rule: unknown action "min" of the body rule`,
+				},
+				{
+					FilterItem{Check: NON_ZERO},
+					`filter item 0 has invalid rule: expected the fields rule, given string`,
+				},
+				{
+					FilterItem{Check: Rule{"fields:range", Range{1, "2"}}},
+					`filter item 0 has invalid rule: "range" expects a pair of numbers, given [1 2]`,
+				},
+				{
+					FilterItem{Check: Rule{"fields:match", `^\d$`}},
+					`filter item 0 has invalid rule: "match" is not applicable to int`,
+				},
+			}
+
+			for _, item := range items {
+				_, err := Filter{item.item}.Compile(Article{})
+
+				g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+				g.Assert(err.Error()).Equal(item.message)
+			}
+		})
+
+		g.It("compiles the rules of the number of the valid fields", func() {
+			for _, rule := range []any{Rule{"fields:range", Range{1, 2}}, &Rule{"fields:in", []int{1, 3}}, Rule{"fields:max", 2}} {
+				filter := Filter{{Field: "Id", Check: Rule{"min", 1}}, {Field: "Title", Check: NON_ZERO}, {Check: rule}}
+
+				compiled, err := filter.Compile(Article{})
+				g.Assert(err).IsNil(rule)
+
+				for _, article := range []Article{{}, {Id: 1}, {Id: 1, Title: "Yellow"}} {
+					g.Assert(compiled.Validate(article)).Equal(filter.Validate(article), rule)
+				}
+			}
+		})
+
+		g.It("resolves the references to the fields", func() {
+			filter := Filter{
+				{Field: "Phone", Check: Rule{"eq", Ref("Title")}},
+				{Field: "Title", Check: AnyOf{Rule{"max", Ref("Id")}, Rule{"prefix", Ref("Address.City")}}},
+				{Field: "Images", Check: Rule{"each:max", Ref("Id")}},
+				{Field: "Date", Check: Rule{"date:max", Ref("Meta.deadline")}},
+				{Field: "Items", Check: Each{{Field: "Sku", Check: Rule{"eq", Ref("Sku")}}}},
+			}
+
+			compiled, err := filter.Compile(Article{})
+			g.Assert(err).IsNil()
+
+			article := Article{Id: 3, Title: "Yellow", Phone: "Blue", Images: []string{"https://"}}
+			g.Assert(compiled.Validate(article)).Equal(filter.Validate(article))
+		})
+
+		g.It("compiles the rules that dereference the pointers", func() {
+			type Player struct {
+				Score *int      `json:"score"`
+				Nick  *string   `json:"nick"`
+				Tags  []*string `json:"tags"`
+			}
+
+			filter := Filter{
+				{Field: "Score", Check: Rule{"in", []int{1, 5}}},
+				{Field: "Nick", Check: Group{Rule{"utf8", nil}, Rule{"alpha", nil}, Rule{"prefix", "pro"}, Rule{"match", `^\w+$`}}},
+				{Field: "Nick", Check: Rule{"notIn", []string{"admin"}}},
+				{Field: "Tags", Check: Rule{"each:lower", nil}},
+			}
+
+			compiled, err := filter.Compile(Player{})
+			g.Assert(err).IsNil()
+
+			score, nick, tag := 5, "proGamer", "Go"
+
+			for _, player := range []Player{{}, {Score: &score, Nick: &nick, Tags: []*string{&tag}}} {
+				g.Assert(compiled.Validate(player)).Equal(filter.Validate(player))
+			}
+
+			_, err = Filter{{Field: "Score", Check: Rule{"prefix", "1"}}}.Compile(Player{})
+			g.Assert(err.Error()).Equal(`field Score has invalid rule: "prefix" is not applicable to int`)
+		})
+
+		g.It("skips the kind checks of the dynamic fields", func() {
+			_, err := Filter{
+				{Field: "Meta.age", Check: Rule{"min", 18}},
+				{Field: "Id", Check: Range{0.5, 1e3}},
+				{Field: "Options.color", Check: Rule{"match", `^#[0-9a-f]{6}$`}},
+				{Field: "Anything", Check: Rule{"each:min", 1}},
+			}.Compile(Article{})
+
+			g.Assert(err).IsNil()
+		})
+	})
+}
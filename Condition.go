@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// Tells whether the filter item applies to the data. The field function
+// looks up the sibling fields of the same structure (or map) by their paths,
+// like the Field of the FilterItem, and returns an invalid value for the
+// missing ones, e.g.
+//
+//	func(field func(path string) reflect.Value) bool {
+//		return field("Delivery").Bool()
+//	}
+type Condition func(field func(path string) reflect.Value) bool
+
+// Holds if the field equals the value. The numbers are compared by their
+// values regardless of the types, e.g. FieldEq("CustomerType", 2) holds
+// for the uint8 field as well
+func FieldEq(path string, value any) Condition {
+	return func(field func(path string) reflect.Value) bool {
+		actual := field(path)
+
+		for actual.Kind() == reflect.Pointer || actual.Kind() == reflect.Interface {
+			actual = actual.Elem()
+		}
+
+		if !actual.IsValid() || !actual.CanInterface() {
+			return false
+		}
+
+		if cmp, ok := compareNumbers(value, actual.Interface()); ok {
+			return cmp == 0
+		}
+
+		// the defined string types, e.g. type Status string
+		if str, ok := value.(string); ok && actual.Kind() == reflect.String {
+			return actual.String() == str
+		}
+
+		return reflect.DeepEqual(actual.Interface(), value)
+	}
+}
+
+// Holds if the field is missing, nil or has the zero value
+func FieldEmpty(path string) Condition {
+	return func(field func(path string) reflect.Value) bool {
+		value := field(path)
+
+		return !value.IsValid() || value.IsZero()
+	}
+}
+
+// Tells whether the conditions of the item hold for the data
+func (v *Validator) applies(item FilterItem, data reflect.Value) bool {
+	if item.When == nil && item.Unless == nil {
+		return true
+	}
+
+	field := func(path string) reflect.Value {
+		value, _, _ := v.lookupField(data, path)
+		return value
+	}
+
+	return (item.When == nil || item.When(field)) && (item.Unless == nil || !item.Unless(field))
+}
@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestErrors .
+
+func TestErrors(t *testing.T) {
+	type Article struct {
+		Id     uint     `json:"id"`
+		Title  string   `json:"title"`
+		Images []string `json:"images"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Errors`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+			}
+
+			errs := filter.Errors(Article{Id: 1})
+
+			g.Assert(len(errs)).Equal(0, errs)
+		})
+
+		g.It("failure with the details of the failed rule", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+			}
+
+			errs := filter.Errors(Article{})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Field).Equal("id")
+			g.Assert(errs[0].Path).Equal("Id")
+			g.Assert(errs[0].Action).Equal("min")
+			g.Assert(errs[0].Proto).Equal(1)
+			g.Assert(errs[0].Value).Equal(uint(0))
+			g.Assert(errs[0].Message).Equal("must be at least 1")
+			g.Assert(errs[0].String()).Equal("id must be at least 1")
+		})
+
+		g.It("failure with the details of the failed group rule", func() {
+			filter := Filter{
+				{
+					Field: "Title",
+					Check: Group{NON_ZERO, Rule{"min", 4}},
+				},
+			}
+
+			errs := filter.Errors(Article{Title: "abc"})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal("min")
+			g.Assert(errs[0].Proto).Equal(4)
+			g.Assert(errs[0].Value).Equal("abc")
+			g.Assert(errs[0].Message).Equal("must contain at least 4 characters")
+		})
+
+		g.It("failure with the details of the failed range rule", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Range{1, 2},
+				},
+			}
+
+			errs := filter.Errors(Article{Id: 3})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal("range")
+			g.Assert(errs[0].Proto).Equal(Range{1, 2})
+			g.Assert(errs[0].Value).Equal(uint(3))
+		})
+
+		g.It("failure with the item of the collection", func() {
+			filter := Filter{
+				{
+					Field: "Images",
+					Check: Rule{"each:min", 5},
+				},
+			}
+
+			errs := filter.Errors(Article{
+				Images: []string{"img01", "img2"},
+			})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal("each:min")
+			g.Assert(errs[0].Value).Equal("img2")
+			g.Assert(errs[0].Message).Equal("item[1] must contain at least 5 characters")
+		})
+
+		g.It("failure with the emptiness of the field", func() {
+			filter := Filter{
+				{
+					Field: "Images",
+					Check: NON_ZERO,
+				},
+			}
+
+			errs := filter.Errors(Article{})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal(NON_ZERO)
+			g.Assert(errs[0].Proto).IsNil()
+			g.Assert(errs[0].Message).Equal(MsgEmpty)
+		})
+
+		g.It("failure without a field when validating the body", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+				{
+					Check: Rule{"fields:min", 1},
+				},
+			}
+
+			errs := filter.Errors(Article{})
+
+			g.Assert(len(errs)).Equal(2, errs)
+			g.Assert(errs[1].Field).Equal("")
+			g.Assert(errs[1].String()).Equal(MsgInvalidBodyVal)
+		})
+
+		g.It("renders the hints the same as Validate", func() {
+			filter := Filter{
+				{
+					Field: "Id",
+					Check: Rule{"min", 1},
+				},
+				{
+					Field: "Images",
+					Check: NON_ZERO,
+				},
+			}
+
+			article := Article{}
+			hints := filter.Errors(article).Hints()
+
+			g.Assert(hints).Equal(filter.Validate(article))
+			g.Assert(hints).Equal([]string{
+				"id must be at least 1",
+				"images " + MsgEmpty,
+			})
+		})
+	})
+}
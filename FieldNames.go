@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Returns the hint name of the struct field, e.g. "phone" of the Phone
+type NameFunc func(field reflect.StructField) string
+
+// Names the fields in the hints with the function, e.g.
+//
+//	v := validator.New(validator.FieldNames(validator.NameByTag("form")))
+//
+// The fields are named by the json tag by default, as well as when
+// the function returns an empty name, see JSONName
+func FieldNames(fn NameFunc) Option {
+	return func(v *Validator) {
+		v.names = fn
+	}
+}
+
+// Names the field by the json tag, or by the Go name if the tag
+// has no name or the field is omitted, e.g. `json:",omitempty"`, `json:"-"`
+func JSONName(field reflect.StructField) string {
+	return tagName(field, "json")
+}
+
+// Names the field by its Go name, e.g. "Phone"
+func GoName(field reflect.StructField) string {
+	return field.Name
+}
+
+// Names the field by the tag of the given key, parsed like the json tag,
+// e.g. NameByTag("form") for `form:"phone,omitempty"`
+func NameByTag(key string) NameFunc {
+	return func(field reflect.StructField) string {
+		return tagName(field, key)
+	}
+}
+
+func tagName(field reflect.StructField, key string) string {
+	tag, exist := field.Tag.Lookup(key)
+	if !exist || tag == "-" {
+		return field.Name
+	}
+
+	// the options, e.g. "omitempty", follow the name
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
+func (v *Validator) fieldName(field reflect.StructField) string {
+	if v.names != nil {
+		if name := v.names(field); name != "" {
+			return name
+		}
+	}
+
+	return JSONName(field)
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Checks how many fields of the set are filled, i.e. not missing, nil or zero.
+// Placed in the filter item without the Field, at any position, e.g.
+//
+//	{Check: validator.AtLeastOne("Email", "Phone")}
+//
+// The hint lists the fields, e.g. "at least 1 of email, phone must be filled"
+type FieldSet struct {
+	// Paths of the fields, like the Field of the FilterItem
+	Fields []string
+
+	// The least number of the filled fields
+	Min int
+
+	// The greatest number of the filled fields, unlimited when zero
+	Max int
+
+	// Either all of the fields are filled or none of them
+	AllOrNone bool
+}
+
+// Requires at least one of the fields to be filled, e.g. an email or a phone
+func AtLeastOne(fields ...string) FieldSet {
+	return FieldSet{Fields: fields, Min: 1}
+}
+
+// Requires exactly one of the fields to be filled, e.g. a card or an IBAN
+func ExactlyOne(fields ...string) FieldSet {
+	return FieldSet{Fields: fields, Min: 1, Max: 1}
+}
+
+// Requires either all of the fields to be filled or none of them,
+// e.g. the latitude and the longitude
+func AllOrNone(fields ...string) FieldSet {
+	return FieldSet{Fields: fields, AllOrNone: true}
+}
+
+// The set limits the number of the fields with either the Min, the Max
+// or the AllOrNone, otherwise it would never fail
+func (set FieldSet) valid() bool {
+	return len(set.Fields) > 0 && set.Min >= 0 && (set.Max == 0 || set.Max >= set.Min) &&
+		(set.AllOrNone != (set.Min > 0 || set.Max > 0))
+}
+
+// Counts the filled fields of the set in the data
+func (v *Validator) checkFieldSet(set FieldSet, data reflect.Value) *ValidationError {
+	if !set.valid() {
+		return newError(MsgInvalidRule)
+	}
+
+	names := make([]string, len(set.Fields))
+	filled := 0
+
+	for n, path := range set.Fields {
+		value, name, exist := v.lookupField(data, path)
+		if !exist {
+			return newError(MsgInvalidRule)
+		}
+
+		names[n] = name
+
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+
+		if value.IsValid() && !value.IsZero() {
+			filled++
+		}
+	}
+
+	var err *ValidationError
+	list := strings.Join(names, ", ")
+
+	switch {
+	case set.AllOrNone:
+		if filled > 0 && filled < len(set.Fields) {
+			err = newError(MsgFieldsAllOrNone, list)
+		}
+
+	case set.Min == set.Max:
+		if filled != set.Min {
+			err = newError(MsgFieldsEq, set.Min, list)
+		}
+
+	case set.Max == 0:
+		if filled < set.Min {
+			err = newError(MsgFieldsMin, set.Min, list)
+		}
+
+	case set.Min == 0:
+		if filled > set.Max {
+			err = newError(MsgFieldsMax, set.Max, list)
+		}
+
+	case filled < set.Min || filled > set.Max:
+		err = newError(MsgFieldsRange, set.Min, set.Max, list)
+	}
+
+	if err != nil {
+		err.describe("FieldSet", reflect.ValueOf(set.Fields), reflect.ValueOf(filled))
+	}
+
+	return err
+}
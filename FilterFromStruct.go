@@ -0,0 +1,412 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TagName        = "validate"
+	MessageTagName = "message"
+)
+
+// Builds the filter from the `validate` tags of the structure fields, e.g.
+//
+//	type Article struct {
+//		Id     uint      `validate:"min=1"`
+//		Sex    uint8     `validate:"range=1..2"`
+//		Images []string  `validate:"nonzero,each:match=^https://"`
+//		Date   time.Time `validate:"optional,date:min=2024-01-01T00:00:00Z"`
+//	}
+//
+// The rules of a field are separated by a comma and result in a Group,
+// and the `message` tag sets the template of the hint.
+// The fields of nested structures produce dotted paths, which the
+// `validate:"optional"` tag of the structure field skips if it is empty,
+// e.g. the nil pointer. The slices or maps of tagged structures produce
+// the Each sub-filter.
+// Returns an error wrapping ErrInvalidRule if a tag cannot be parsed
+func FilterFromStruct(data any) (Filter, error) {
+	return std.FilterFromStruct(data)
+}
+
+// Builds the filter from the `validate` tags of the structure fields,
+// where the tags may use the rules and the modifiers of the Validator
+func (v *Validator) FilterFromStruct(data any) (Filter, error) {
+	typ := reflect.TypeOf(data)
+
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a structure, given %v", ErrInvalidRule, typ)
+	}
+
+	return v.filterFromType(typ, "", map[reflect.Type]bool{})
+}
+
+func (v *Validator) filterFromType(typ reflect.Type, prefix string, visited map[reflect.Type]bool) (Filter, error) {
+	filter := Filter{}
+
+	// recursive types would produce endless paths
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	for n := 0; n < typ.NumField(); n++ {
+		field := typ.Field(n)
+		path := prefix + field.Name
+		tag, tagged := field.Tag.Lookup(TagName)
+
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		// the "optional" alone skips the nested fields of the empty
+		// structure, e.g. the nil pointer, rather than checks the field itself
+		optional := tagged && v.isOptionalTag(tag) && isNestedStruct(field.Type)
+
+		if tagged && !optional {
+			item, err := v.parseTag(path, tag)
+			if err != nil {
+				return nil, err
+			}
+
+			item.Message = field.Tag.Get(MessageTagName)
+
+			filter = append(filter, item)
+		}
+
+		nested, err := v.nestedFilter(field.Type, path, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		if optional {
+			skipEmpty(nested, path)
+		}
+
+		filter = append(filter, nested...)
+	}
+
+	return filter, nil
+}
+
+// Builds the filter items of the nested structure,
+// or the Each sub-filter of the slice or map of structures
+func (v *Validator) nestedFilter(typ reflect.Type, path string, visited map[reflect.Type]bool) (Filter, error) {
+	elem := typ
+
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	switch elem.Kind() {
+	case reflect.Struct:
+		if visited[elem] || elem == refTypTime {
+			return nil, nil
+		}
+
+		return v.filterFromType(elem, path+".", visited)
+
+	case reflect.Array, reflect.Slice, reflect.Map:
+		item := elem.Elem()
+
+		for item.Kind() == reflect.Pointer {
+			item = item.Elem()
+		}
+
+		if item.Kind() != reflect.Struct || visited[item] || item == refTypTime {
+			return nil, nil
+		}
+
+		filter, err := v.filterFromType(item, "", visited)
+		if err != nil || len(filter) == 0 {
+			return nil, err
+		}
+
+		return Filter{{Field: path, Check: Each(filter), Optional: true}}, nil
+	}
+
+	return nil, nil
+}
+
+// Tells whether the field is the structure, or the pointer to it,
+// the fields of which produce the nested filter items
+func isNestedStruct(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Struct && typ != refTypTime
+}
+
+func (v *Validator) isOptionalTag(tag string) bool {
+	for _, entry := range v.splitTag(tag) {
+		if entry != "optional" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Skips the nested filter items if the structure at the path is empty
+func skipEmpty(filter Filter, path string) {
+	empty := FieldEmpty(path)
+
+	for n := range filter {
+		unless := filter[n].Unless
+		filter[n].Unless = empty
+
+		if unless != nil {
+			filter[n].Unless = func(field func(path string) reflect.Value) bool {
+				return empty(field) || unless(field)
+			}
+		}
+	}
+}
+
+// Parses the tag of the field, e.g. "optional,min=1,max=10".
+// The "all" flag reports every failed rule, see AllOf
+func (v *Validator) parseTag(path, tag string) (FilterItem, error) {
+	item := FilterItem{Field: path}
+	group := Group{}
+	collectAll := false
+
+	for _, entry := range v.splitTag(tag) {
+		action, value, _ := strings.Cut(entry, "=")
+
+		switch action {
+		case "optional":
+			item.Optional = true
+
+		case "nonzero", "required":
+			group = append(group, NON_ZERO)
+
+		case "all":
+			collectAll = true
+
+		default:
+			rule, err := v.parseTagRule(action, value)
+			if err != nil {
+				return item, fmt.Errorf("field %s %w %q: %v", path, ErrInvalidRule, entry, err)
+			}
+
+			group = append(group, rule)
+		}
+	}
+
+	switch len(group) {
+	case 0:
+		return item, fmt.Errorf("field %s %w %q: no rules", path, ErrInvalidRule, tag)
+
+	case 1:
+		item.Check = group[0]
+
+	default:
+		item.Check = group
+
+		if collectAll {
+			item.Check = AllOf(group)
+		}
+	}
+
+	return item, nil
+}
+
+// Splits the tag by commas. A comma that does not precede a known
+// action belongs to the value, e.g. "match=^\d{1,3}$"
+func (v *Validator) splitTag(tag string) []string {
+	var entries []string
+
+	for _, entry := range strings.Split(tag, ",") {
+		action, _, _ := strings.Cut(entry, "=")
+
+		if len(entries) > 0 && !v.isTagAction(strings.TrimSpace(action)) {
+			entries[len(entries)-1] += "," + entry
+			continue
+		}
+
+		entries = append(entries, strings.TrimSpace(entry))
+	}
+
+	return entries
+}
+
+func (v *Validator) isTagAction(action string) bool {
+	switch action {
+	case "optional", "nonzero", "required", "all":
+		return true
+	}
+
+	_, err := v.parseTagRule(action, "")
+	return err != errUnknownAction
+}
+
+var (
+	errUnknownAction = errors.New("unknown action")
+	errEmptyValue    = errors.New("expected a value")
+)
+
+func (v *Validator) parseTagRule(action, value string) (any, error) {
+	switch action {
+	case "min", "max", "eq", "year", "each:min", "each:max", "each:eq":
+		proto, err := parseTagNumber(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return Rule{action, proto}, nil
+
+	case "range", "each:range":
+		valMin, valMax, found := strings.Cut(value, "..")
+		if !found {
+			return nil, fmt.Errorf("expected a range in the form min..max")
+		}
+
+		protoMin, err := parseTagNumber(valMin)
+		if err != nil {
+			return nil, err
+		}
+
+		protoMax, err := parseTagNumber(valMax)
+		if err != nil {
+			return nil, err
+		}
+
+		if action == "range" {
+			return Range{protoMin, protoMax}, nil
+		}
+
+		return Rule{action, Range{protoMin, protoMax}}, nil
+
+	case "in", "notIn":
+		if value == "" {
+			return nil, errEmptyValue
+		}
+
+		return Rule{action, parseTagList(value)}, nil
+
+	case "contains", "notContains", "prefix", "suffix",
+		"icontains", "inotContains", "iprefix", "isuffix":
+		if value == "" {
+			return nil, errEmptyValue
+		}
+
+		return Rule{action, value}, nil
+
+	case "alpha", "alnum", "numeric", "ascii", "printable", "lower", "upper", "noSpace":
+		switch value {
+		case "":
+			return Rule{action, nil}, nil
+
+		case ASCII:
+			return Rule{action, ASCII}, nil
+		}
+
+		return nil, fmt.Errorf("expected no value or %q, given %q", ASCII, value)
+
+	case "utf8":
+		if value != "" {
+			return nil, fmt.Errorf("expected no value, given %q", value)
+		}
+
+		return Rule{action, nil}, nil
+
+	case "match", "each:match":
+		if value == "" {
+			return nil, errEmptyValue
+		}
+
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, err
+		}
+
+		return Rule{action, value}, nil
+
+	case "date:min", "date:max", "date:eq":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return nil, err
+		}
+
+		return Rule{action, value}, nil
+
+	case "time:min", "time:max", "time:eq":
+		proto, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return Rule{action, proto}, nil
+	}
+
+	// the modifiers wrap any of the rules, e.g. "trim:min=3", "each:trim:min=3"
+	if name, rest, found := strings.Cut(action, ":"); found {
+		if _, found := v.modifier(name); found || name == "each" {
+			rule, err := v.parseTagRule(rest, value)
+			if err != nil {
+				return nil, err
+			}
+
+			switch rule := rule.(type) {
+			case Range:
+				return Rule{action, rule}, nil
+
+			case Rule:
+				return Rule{name + ":" + rule[0].(string), rule[1]}, nil
+			}
+		}
+	}
+
+	// the custom rules take the raw value as the prototype, e.g. "sku" or "each:iban=UA"
+	if _, found := v.rule(strings.TrimPrefix(action, "each:")); found {
+		if value == "" {
+			return Rule{action, nil}, nil
+		}
+
+		return Rule{action, value}, nil
+	}
+
+	return nil, errUnknownAction
+}
+
+// Parses the values separated by "|", e.g. "draft|published". The values
+// are numbers if all of them are numeric, otherwise strings
+func parseTagList(value string) []any {
+	items := strings.Split(value, "|")
+	list := make([]any, len(items))
+
+	for n, item := range items {
+		num, err := parseTagNumber(item)
+		if err != nil {
+			for n, item := range items {
+				list[n] = item
+			}
+
+			return list
+		}
+
+		list[n] = num
+	}
+
+	return list
+}
+
+func parseTagNumber(value string) (any, error) {
+	if num, err := strconv.Atoi(value); err == nil {
+		return num, nil
+	}
+
+	if num, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(num) {
+		return num, nil
+	}
+
+	return nil, fmt.Errorf("expected a number, given %q", value)
+}
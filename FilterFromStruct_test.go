@@ -0,0 +1,296 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestFilterFromStruct .
+
+func TestFilterFromStruct(t *testing.T) {
+	type LineItem struct {
+		Sku string `json:"sku" validate:"min=3"`
+	}
+
+	type Address struct {
+		City string `json:"city" validate:"nonzero,max=32"`
+	}
+
+	type Article struct {
+		Id      uint       `json:"id" validate:"min=1"`
+		Sex     uint8      `json:"sex" validate:"range=1..2"`
+		Phone   string     `json:"phone" validate:"optional,match=^\\+38\\d{10}$"`
+		Code    string     `json:"code" validate:"match=^\\d{2,4}$"`
+		Images  []string   `json:"images" validate:"nonzero,each:match=^https://"`
+		Date    time.Time  `json:"date" validate:"optional,date:min=2024-01-01T00:00:00Z"`
+		Address Address    `json:"address"`
+		Items   []LineItem `json:"items"`
+		Secret  string     `validate:"-"`
+		Notes   string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`FilterFromStruct`, func() {
+		g.It("builds the equivalent filter", func() {
+			filter, err := FilterFromStruct(Article{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Id", Check: Rule{"min", 1}},
+				{Field: "Sex", Check: Range{1, 2}},
+				{Field: "Phone", Check: Rule{"match", `^\+38\d{10}$`}, Optional: true},
+				{Field: "Code", Check: Rule{"match", `^\d{2,4}$`}},
+				{Field: "Images", Check: Group{NON_ZERO, Rule{"each:match", "^https://"}}},
+				{Field: "Date", Check: Rule{"date:min", "2024-01-01T00:00:00Z"}, Optional: true},
+				{Field: "Address.City", Check: Group{NON_ZERO, Rule{"max", 32}}},
+				{Field: "Items", Check: Each{{Field: "Sku", Check: Rule{"min", 3}}}, Optional: true},
+			})
+		})
+
+		g.It("builds the filter from a pointer", func() {
+			filter, err := FilterFromStruct(&Address{})
+
+			g.Assert(err).IsNil()
+			g.Assert(len(filter)).Equal(1)
+		})
+
+		g.It("validates the data with the built filter", func() {
+			filter, err := FilterFromStruct(Article{})
+			g.Assert(err).IsNil()
+
+			hints := filter.Validate(Article{
+				Sex:     3,
+				Code:    "12345",
+				Images:  []string{"http://img.it/1.jpg"},
+				Address: Address{City: "Kyiv"},
+				Items:   []LineItem{{Sku: "ab"}},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"id must be at least 1",
+				"sex must be in the range 1..2",
+				"code " + MsgNotValid,
+				"images item[0] " + MsgNotValid,
+				"items[0].sku must contain at least 3 characters",
+			})
+		})
+
+		g.It("builds the time and each:range rules", func() {
+			type Schedule struct {
+				Start time.Time `validate:"time:max=1705337534239050689"`
+				Pages []int     `validate:"each:range=1..10,each:eq=5"`
+			}
+
+			filter, err := FilterFromStruct(Schedule{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Start", Check: Rule{"time:max", int64(1705337534239050689)}},
+				{Field: "Pages", Check: Group{Rule{"each:range", Range{1, 10}}, Rule{"each:eq", 5}}},
+			})
+		})
+
+		g.It("builds the templates of the hints", func() {
+			filter, err := FilterFromStruct(struct {
+				Phone string `validate:"match=^\\+38\\d{10}$" message:"Please enter a phone number in +38XXXXXXXXXX format"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{{
+				Field:   "Phone",
+				Check:   Rule{"match", `^\+38\d{10}$`},
+				Message: "Please enter a phone number in +38XXXXXXXXXX format",
+			}})
+		})
+
+		g.It("builds the collect-all group", func() {
+			filter, err := FilterFromStruct(struct {
+				Password string `validate:"all,nonzero,min=8,match=\\d"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{{
+				Field: "Password",
+				Check: AllOf{NON_ZERO, Rule{"min", 8}, Rule{"match", `\d`}},
+			}})
+		})
+
+		g.It("builds the membership rules", func() {
+			filter, err := FilterFromStruct(struct {
+				Status string   `validate:"in=draft|published|archived"`
+				Sex    uint8    `validate:"in=1|2"`
+				Tags   []string `validate:"each:notIn=admin|root"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Status", Check: Rule{"in", []any{"draft", "published", "archived"}}},
+				{Field: "Sex", Check: Rule{"in", []any{1, 2}}},
+				{Field: "Tags", Check: Rule{"each:notIn", []any{"admin", "root"}}},
+			})
+		})
+
+		g.It("builds the string content rules", func() {
+			filter, err := FilterFromStruct(struct {
+				Url   string   `validate:"prefix=https://,notContains=..,isuffix=.PNG"`
+				Hosts []string `validate:"each:icontains=example"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Url", Check: Group{Rule{"prefix", "https://"}, Rule{"notContains", ".."}, Rule{"isuffix", ".PNG"}}},
+				{Field: "Hosts", Check: Rule{"each:icontains", "example"}},
+			})
+		})
+
+		g.It("builds the character class rules", func() {
+			filter, err := FilterFromStruct(struct {
+				Login string   `validate:"alnum=ascii,lower"`
+				Tags  []string `validate:"each:noSpace"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Login", Check: Group{Rule{"alnum", ASCII}, Rule{"lower", nil}}},
+				{Field: "Tags", Check: Rule{"each:noSpace", nil}},
+			})
+
+			_, err = FilterFromStruct(struct {
+				Login string `validate:"alpha=latin"`
+			}{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("builds the byte length and UTF-8 rules", func() {
+			filter, err := FilterFromStruct(struct {
+				Title string   `validate:"utf8,bytes:max=255"`
+				Tags  []string `validate:"each:utf8,each:bytes:range=1..32"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Title", Check: Group{Rule{"utf8", nil}, Rule{"bytes:max", 255}}},
+				{Field: "Tags", Check: Group{Rule{"each:utf8", nil}, Rule{"each:bytes:range", Range{1, 32}}}},
+			})
+
+			_, err = FilterFromStruct(struct {
+				Title string `validate:"utf8=strict"`
+			}{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("skips the optional nested structure if it is empty", func() {
+			type Contact struct {
+				Phone string `json:"phone" validate:"min=10"`
+			}
+
+			type Profile struct {
+				Billing  *Address `json:"billing" validate:"optional"`
+				Shipping *Address `json:"shipping"`
+				Contact  Contact  `json:"contact" validate:"optional"`
+			}
+
+			filter, err := FilterFromStruct(Profile{})
+			g.Assert(err).IsNil()
+			g.Assert(len(filter)).Equal(3)
+
+			g.Assert(filter.Validate(Profile{})).Equal([]string{
+				"shipping.city " + MsgEmpty,
+			})
+
+			g.Assert(filter.Validate(Profile{
+				Billing:  &Address{},
+				Shipping: &Address{City: "Kyiv"},
+				Contact:  Contact{Phone: "12345"},
+			})).Equal([]string{
+				"billing.city " + MsgEmpty,
+				"contact.phone must contain at least 10 characters",
+			})
+		})
+
+		g.It("failure when given not a structure", func() {
+			_, err := FilterFromStruct([]string{})
+
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("failure when given an invalid tag", func() {
+			items := []any{
+				struct {
+					Age uint `validate:"mni=1"`
+				}{},
+				struct {
+					Age uint `validate:"min=one"`
+				}{},
+				struct {
+					Price float64 `validate:"min=NaN"`
+				}{},
+				struct {
+					Age uint `validate:"range=1"`
+				}{},
+				struct {
+					Age uint `validate:"range=1..x"`
+				}{},
+				struct {
+					Name string `validate:"match=^(abc$"`
+				}{},
+				struct {
+					Date time.Time `validate:"date:min=2024-01-01"`
+				}{},
+				struct {
+					Date time.Time `validate:"time:min=now"`
+				}{},
+				struct {
+					Name string `validate:"optional"`
+				}{},
+				struct {
+					Name string `validate:"contains"`
+				}{},
+				struct {
+					Name string `validate:"trim:iprefix="`
+				}{},
+				struct {
+					Status string `validate:"in="`
+				}{},
+				struct {
+					Status string `validate:"notIn"`
+				}{},
+				struct {
+					Name string `validate:"match="`
+				}{},
+				struct {
+					Tags []string `validate:"each:match="`
+				}{},
+				struct {
+					Nested struct {
+						Age uint `validate:"mni=1"`
+					}
+				}{},
+			}
+
+			for _, item := range items {
+				_, err := FilterFromStruct(item)
+
+				g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+			}
+		})
+
+		g.It("failure with a descriptive message", func() {
+			_, err := FilterFromStruct(struct {
+				Age uint `validate:"mni=1"`
+			}{})
+
+			g.Assert(err.Error()).Equal(`field Age has invalid rule "mni=1": unknown action`)
+
+			_, err = FilterFromStruct(struct {
+				Status string `validate:"in="`
+			}{})
+
+			g.Assert(err.Error()).Equal(`field Status has invalid rule "in=": expected a value`)
+		})
+	})
+}
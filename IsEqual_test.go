@@ -912,4 +912,83 @@ func TestIsEqual(t *testing.T) {
 		})
 
 	})
+
+	// ................................
+	// float
+
+	g.Describe("float", func() {
+		items := [][4]any{
+			{"%[2]v == %[1]v", 1.5, 1.5, true},
+			{"%[2]v == %[1]v", 1.5, 1.4999, false},
+			{"%[2]v == %[1]v", 1.5, 1.5001, false},
+			{"%[2]v == %[1]v", 1, 1.0, true},
+			{"%[2]v == %[1]v", 1, 0.999, false},
+			{"%[2]v == %[1]v", uint8(1), 1.001, false},
+			{"%[2]v == %[1]v", 1.5, 2, false},
+			{"%[2]v == %[1]v", 1.5, 1, false},
+			{"%[2]v == %[1]v", float32(0.5), 0.5, true},
+			{"%[2]v == %[1]v", 0.5, float32(0.25), false},
+			{"%[2]v == %[1]v", float64(1 << 53), int64(1<<53 + 1), false},
+			{"%[2]v == %[1]v", int64(1<<53 + 1), float64(1 << 53), false},
+			{"%[2]v == %[1]v", MAX_INT64, float64(math.MaxInt64), false},
+			{"%[2]v == %[1]v", MIN_INT64, float64(math.MinInt64), true},
+			{"%[2]v == %[1]v", MAX_UINT64, float64(math.MaxUint64), false},
+			{"%[2]v == %[1]v", MIN_UINT, -0.5, false},
+			{"%[2]v == %[1]v", -0.5, MIN_UINT, false},
+			{"%[2]v == %[1]v", MAX_INT64, math.Inf(1), false},
+			{"%[2]v == %[1]v", MIN_INT64, math.Inf(-1), false},
+			{"%[2]v == %[1]v", math.Inf(1), math.Inf(1), true},
+			{"%[2]v == %[1]v", math.NaN(), 1.5, false},
+			{"%[2]v == %[1]v", 1.5, math.NaN(), false},
+			{"%[2]v == %[1]v", 1, math.NaN(), false},
+			{"%[2]v == %[1]v", math.NaN(), math.NaN(), false},
+			{"%[2]v == %[1]v", "1.5", 1.5, false},
+			{"%[2]v == %[1]v", 1.5, "1.5", false},
+		}
+
+		for _, item := range items {
+			item := item // (!) Dont remove, it saves the context
+			title := fmt.Sprintf("return %[3]t if "+item[0].(string)+" (%[2]T, %[1]T)", item[1], item[2], item[3])
+
+			g.It(title, func() {
+				result := IsEqual(item[1], item[2])
+				g.Assert(result).Equal(item[3], fmt.Sprintf("Expect(%#v) Got(%#v)", item[3], result))
+			})
+		}
+	})
+
+	// ................................
+	// defined types & mixed signedness
+
+	g.Describe("defined types & mixed signedness", func() {
+		type (
+			Age   uint8
+			Score int16
+			Price float64
+		)
+
+		items := [][4]any{
+			{"%[2]v == %[1]v", 18, Age(20), false},
+			{"%[2]v == %[1]v", Age(20), 20, true},
+			{"%[2]v == %[1]v", Age(20), Score(-20), false},
+			{"%[2]v == %[1]v", Score(-5), Price(-5), true},
+			{"%[2]v == %[1]v", Price(9.99), Age(10), false},
+			{"%[2]v == %[1]v", uint8(200), int8(-1), false},
+			{"%[2]v == %[1]v", int8(-1), uint8(200), false},
+			{"%[2]v == %[1]v", MAX_UINT64, int64(-1), false},
+			{"%[2]v == %[1]v", int64(-1), MAX_UINT64, false},
+			{"%[2]v == %[1]v", MAX_UINT64, MAX_INT64, false},
+			{"%[2]v == %[1]v", Age(1), true, false},
+		}
+
+		for _, item := range items {
+			item := item // (!) Dont remove, it saves the context
+			title := fmt.Sprintf("return %[3]t if "+item[0].(string)+" (%[2]T, %[1]T)", item[1], item[2], item[3])
+
+			g.It(title, func() {
+				result := IsEqual(item[1], item[2])
+				g.Assert(result).Equal(item[3], fmt.Sprintf("Expect(%#v) Got(%#v)", item[3], result))
+			})
+		}
+	})
 }
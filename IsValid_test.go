@@ -2,6 +2,7 @@ package validator
 
 import (
 	"math"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
@@ -8495,3 +8496,438 @@ func TestIsValidFieldsMod(t *testing.T) {
 		})
 	})
 }
+
+// go test -v -run TestIsValidNested .
+
+func TestIsValidNested(t *testing.T) {
+	type Address struct {
+		City string
+	}
+
+	type Article struct {
+		Address Address
+		Billing *Address
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Nested fields`, func() {
+		filter := Filter{
+			{
+				Field: "Address.City",
+				Check: Rule{"min", 2},
+			},
+			{
+				Field: "Billing.City",
+				Check: Rule{"min", 2},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			success := filter.IsValid(Article{
+				Address: Address{City: "Kyiv"},
+				Billing: &Address{City: "Lviv"},
+			})
+
+			g.Assert(success).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			success := filter.IsValid(Article{
+				Address: Address{City: "Kyiv"},
+			})
+
+			g.Assert(success).IsFalse()
+		})
+	})
+}
+
+func TestIsValidFloat(t *testing.T) {
+	type Product struct {
+		Price  float64
+		Rating float32
+		Prices []float64
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Floating-point values`, func() {
+		filter := Filter{
+			{
+				Field: "Price",
+				Check: Rule{"min", 0.01},
+			},
+			{
+				Field: "Rating",
+				Check: Range{1, 5},
+			},
+			{
+				Field: "Prices",
+				Check: Rule{"each:max", 10},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			success := filter.IsValid(Product{
+				Price:  9.99,
+				Rating: 4.5,
+				Prices: []float64{0.5, 10},
+			})
+
+			g.Assert(success).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			success := filter.IsValid(Product{
+				Price:  9.99,
+				Rating: 4.5,
+				Prices: []float64{10.01},
+			})
+
+			g.Assert(success).IsFalse()
+		})
+
+		g.It("failure when given NaN", func() {
+			success := filter.IsValid(Product{
+				Price:  math.NaN(),
+				Rating: 4.5,
+			})
+
+			g.Assert(success).IsFalse()
+		})
+	})
+}
+
+func TestIsValidDefinedTypes(t *testing.T) {
+	type Age uint8
+
+	type Person struct {
+		Age Age
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Defined numeric types`, func() {
+		filter := Filter{
+			{
+				Field: "Age",
+				Check: Range{18, 99},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Person{Age: 18})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Person{Age: 17})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidAllOf(t *testing.T) {
+	type Account struct {
+		Password string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rule AllOf`, func() {
+		filter := Filter{
+			{
+				Field: "Password",
+				Check: AllOf{Rule{"min", 8}, Rule{"match", `\d`}},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Account{Password: "secret123"})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Account{Password: "secret12"})).IsTrue()
+			g.Assert(filter.IsValid(Account{Password: "secretpassword"})).IsFalse()
+			g.Assert(filter.IsValid(Account{Password: "secret"})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidShortCircuit(t *testing.T) {
+	type Row struct {
+		Id   int
+		Name string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`IsValid`, func() {
+		calls := 0
+
+		v := New()
+		v.RegisterRule("counted", func(proto, value reflect.Value) string {
+			calls++
+			return MsgNotValid
+		})
+
+		filter := Filter{
+			{Field: "Id", Check: Rule{"counted", nil}},
+			{Field: "Name", Check: Rule{"counted", nil}},
+		}
+
+		g.BeforeEach(func() {
+			calls = 0
+		})
+
+		g.It("stops at the first error", func() {
+			g.Assert(v.IsValid(filter, Row{})).IsFalse()
+			g.Assert(calls).Equal(1)
+		})
+
+		g.It("stops at the first error of the compiled filter", func() {
+			compiled, err := v.Compile(filter, Row{})
+			g.Assert(err).IsNil()
+
+			g.Assert(compiled.IsValid(Row{})).IsFalse()
+			g.Assert(calls).Equal(1)
+
+			g.Assert(compiled.IsValid(struct{}{})).IsFalse()
+		})
+
+		g.It("agrees with the Validator options", func() {
+			filter := Filter{{Field: "Id", Check: Rule{"min", 1}}}
+
+			g.Assert(New(MaxErrors(1)).IsValid(filter, Row{})).IsFalse()
+			g.Assert(New(FailFast()).IsValid(filter, Row{Id: 1})).IsTrue()
+		})
+	})
+}
+
+func TestIsValidCombinators(t *testing.T) {
+	type Contact struct {
+		Login   string
+		Contact string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rules AnyOf, OneOf, Not`, func() {
+		filter := Filter{
+			{Field: "Login", Check: Not{Rule{"match", "^admin"}}},
+			{Field: "Contact", Check: AnyOf{Rule{"match", `^\d{10}$`}, Rule{"match", `@`}}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Contact{Login: "user", Contact: "0501234567"})).IsTrue()
+			g.Assert(filter.IsValid(Contact{Login: "user", Contact: "me@example.com"})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Contact{Login: "admin", Contact: "me@example.com"})).IsFalse()
+			g.Assert(filter.IsValid(Contact{Login: "user", Contact: "me"})).IsFalse()
+
+			oneOf := Filter{{Field: "Contact", Check: OneOf{Rule{"match", `^\d+$`}, Rule{"min", 4}}}}
+
+			g.Assert(oneOf.IsValid(Contact{Contact: "12"})).IsTrue()
+			g.Assert(oneOf.IsValid(Contact{Contact: "1234"})).IsFalse()
+		})
+
+		g.It("failure when the rule does not apply to the value", func() {
+			type Account struct {
+				Id    int
+				Login *string
+			}
+
+			g.Assert(Filter{{Field: "Id", Check: Not{Rule{"prefix", "admin"}}}}.IsValid(Account{})).IsFalse()
+			g.Assert(Filter{{Field: "Login", Check: Not{Rule{"match", "^admin"}}}}.IsValid(Account{})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidConditions(t *testing.T) {
+	type Customer struct {
+		CustomerType int
+		CompanyName  string
+		Email        *string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Conditions`, func() {
+		email := "me@example.com"
+
+		filter := Filter{
+			{Field: "CompanyName", Check: NON_ZERO, When: FieldEq("CustomerType", uint(2))},
+			{Field: "CompanyName", Check: Rule{"min", 3}, Unless: FieldEmpty("Email")},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Customer{CustomerType: 1})).IsTrue()
+			g.Assert(filter.IsValid(Customer{CustomerType: 2, CompanyName: "ACME"})).IsTrue()
+			g.Assert(filter.IsValid(Customer{CompanyName: "ACME", Email: &email})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Customer{CustomerType: 2})).IsFalse()
+			g.Assert(filter.IsValid(Customer{CompanyName: "AC", Email: &email})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidRef(t *testing.T) {
+	type Booking struct {
+		Password        string
+		PasswordConfirm string
+		MinPrice        int
+		MaxPrice        int
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rule with the field reference`, func() {
+		filter := Filter{
+			{Field: "PasswordConfirm", Check: Rule{"eq", Ref("Password")}},
+			{Field: "MaxPrice", Check: Rule{"min", Ref("MinPrice")}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Booking{Password: "secret", PasswordConfirm: "secret", MinPrice: 1, MaxPrice: 1})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Booking{Password: "secret", PasswordConfirm: "secret1"})).IsFalse()
+			g.Assert(filter.IsValid(Booking{MinPrice: 2, MaxPrice: 1})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidFieldSet(t *testing.T) {
+	type Contact struct {
+		Email string
+		Phone string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rule FieldSet`, func() {
+		filter := Filter{{Check: AtLeastOne("Email", "Phone")}}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Contact{Email: "me@example.com"})).IsTrue()
+			g.Assert(filter.IsValid(Contact{Phone: "+380501234567"})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Contact{})).IsFalse()
+			g.Assert(Filter{{Check: ExactlyOne("Email", "Phone")}}.IsValid(Contact{Email: "a", Phone: "b"})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidIn(t *testing.T) {
+	type Article struct {
+		Status string
+		Sex    uint8
+		Tags   []string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rules "in", "notIn"`, func() {
+		filter := Filter{
+			{Field: "Status", Check: Rule{"in", []any{"draft", "published"}}},
+			{Field: "Sex", Check: Rule{"notIn", []any{0}}},
+			{Field: "Tags", Check: Rule{"each:in", []any{"go", "rust"}}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Article{Status: "draft", Sex: 1, Tags: []string{"go"}})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Article{Status: "deleted", Sex: 1})).IsFalse()
+			g.Assert(filter.IsValid(Article{Status: "draft"})).IsFalse()
+			g.Assert(filter.IsValid(Article{Status: "draft", Sex: 1, Tags: []string{"java"}})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidContains(t *testing.T) {
+	type Page struct {
+		Url    string
+		Images []string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rules "contains", "prefix", "suffix"`, func() {
+		filter := Filter{
+			{Field: "Url", Check: Group{Rule{"iprefix", "https://"}, Rule{"notContains", ".."}}},
+			{Field: "Images", Check: Rule{"each:suffix", ".png"}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Page{Url: "HTTPS://example.com", Images: []string{"1.png"}})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Page{Url: "http://example.com"})).IsFalse()
+			g.Assert(filter.IsValid(Page{Url: "https://example.com/../"})).IsFalse()
+			g.Assert(filter.IsValid(Page{Url: "https://example.com", Images: []string{"1.jpg"}})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidCharClasses(t *testing.T) {
+	type Profile struct {
+		Login string
+		Pin   string
+		Tags  []string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Character class rules`, func() {
+		filter := Filter{
+			{Field: "Login", Check: Group{Rule{"alnum", ASCII}, Rule{"lower", nil}}},
+			{Field: "Pin", Check: Rule{"numeric", nil}},
+			{Field: "Tags", Check: Rule{"each:noSpace", nil}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Profile{Login: "olena90", Pin: "1234", Tags: []string{"go"}})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Profile{Login: "Olena90", Pin: "1234"})).IsFalse()
+			g.Assert(filter.IsValid(Profile{Login: "олена", Pin: "1234"})).IsFalse()
+			g.Assert(filter.IsValid(Profile{Login: "olena", Pin: "12 34"})).IsFalse()
+			g.Assert(filter.IsValid(Profile{Login: "olena", Pin: "1234", Tags: []string{"a b"}})).IsFalse()
+		})
+	})
+}
+
+func TestIsValidBytes(t *testing.T) {
+	type Account struct {
+		Name string
+		Tags []string
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`The "bytes" modifier and the "utf8" rule`, func() {
+		filter := Filter{
+			{Field: "Name", Check: Group{Rule{"utf8", nil}, Rule{"bytes:max", 8}}},
+			{Field: "Tags", Check: Rule{"each:utf8", nil}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(filter.IsValid(Account{Name: "Оля", Tags: []string{"go"}})).IsTrue()
+		})
+
+		g.It("failure when given invalid values", func() {
+			g.Assert(filter.IsValid(Account{Name: "Олена"})).IsFalse()
+			g.Assert(filter.IsValid(Account{Name: "\xff"})).IsFalse()
+			g.Assert(filter.IsValid(Account{Name: "Оля", Tags: []string{"\xc3"}})).IsFalse()
+		})
+	})
+}
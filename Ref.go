@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Refers to the other field of the same structure (or map) by its path,
+// like the Field of the FilterItem. Used as the prototype of the rule, e.g.
+//
+//	Rule{"date:min", Ref("StartDate")}
+//	Rule{"eq", Ref("Password")}
+//	Range{Ref("MinPrice"), Ref("MaxPrice")}
+//
+// The hint names the referenced field instead of its value, e.g.
+// "endDate must be at least startDate". The "eq" compares the strings
+// by their values rather than the length. The rule passes if the referenced
+// field is nil, and reports MsgInvalidRule if it is missing. The compiled
+// filter verifies the referenced fields and their kinds up front
+type Ref string
+
+func hasRef(protos []any) bool {
+	for _, proto := range protos {
+		if _, ok := proto.(Ref); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compares the value with the prototypes, where the references are
+// resolved against the data
+func (v *Validator) compareRef(action string, protos []any, data, value reflect.Value) *ValidationError {
+	resolved := make([]any, len(protos))
+	names := make([]any, len(protos))
+
+	for n, proto := range protos {
+		ref, ok := proto.(Ref)
+		if !ok {
+			resolved[n], names[n] = proto, proto
+			continue
+		}
+
+		refValue, name, exist := v.lookupField(data, string(ref))
+		if !exist {
+			err := newError(MsgInvalidRule)
+			err.describe(action, reflect.ValueOf(proto), value)
+
+			return err
+		}
+
+		for refValue.Kind() == reflect.Pointer || refValue.Kind() == reflect.Interface {
+			refValue = refValue.Elem()
+		}
+
+		// nothing to compare with, e.g. the optional start date
+		if !refValue.IsValid() {
+			return nil
+		}
+
+		if !refValue.CanInterface() {
+			err := newError(MsgInvalidRule)
+			err.describe(action, reflect.ValueOf(proto), value)
+
+			return err
+		}
+
+		resolved[n], names[n] = refValue.Interface(), name
+	}
+
+	proto := reflect.ValueOf(resolved[0])
+	if action == "range" {
+		proto = reflect.ValueOf(Range{resolved[0], resolved[1]})
+	}
+
+	// the strings are compared by their values, e.g. the password confirmation
+	if action == "eq" && proto.Kind() == reflect.String && value.Kind() == reflect.String {
+		if proto.String() == value.String() {
+			return nil
+		}
+
+		err := newError(MsgEq, names...)
+		err.describe(action, proto, value)
+
+		return err
+	}
+
+	err := v.compare(action, proto, value)
+	if err == nil {
+		return nil
+	}
+
+	// name the referenced fields instead of their values, e.g. "must be at least startDate".
+	// The prefix of the message remains, e.g. "item[3] " of the each: modifier
+	switch format := messageFormats[err.Code]; format {
+	case MsgMin, MsgMax, MsgEq, MsgRange:
+		if len(err.Args) != len(names) {
+			break
+		}
+
+		if prefix, found := strings.CutSuffix(err.Message, fmt.Sprintf(format, err.Args...)); found {
+			err.Message = prefix + fmt.Sprintf(format, names...)
+			err.Args = names
+		}
+	}
+
+	return err
+}
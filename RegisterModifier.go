@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Applies the rule that follows the modifier, e.g. "min" of the "trim:min",
+// to the value derived from the field value. The check returns the error of
+// the rule, which the modifier returns as is or wraps with %w, e.g.
+//
+//	fmt.Errorf("key[%v] %w", key, err)
+//
+// A modifier may call the check several times, e.g. once per map key
+type ModifierFunc func(value reflect.Value, check func(value reflect.Value) error) error
+
+// The modifiers built into the package, besides each:, date:, time: and fields:
+var builtinModifiers = map[string]ModifierFunc{
+	"trim":  modifyTrim,
+	"abs":   modifyAbs,
+	"keys":  modifyKeys,
+	"len":   modifyLen,
+	"bytes": modifyLen,
+}
+
+// Registers the custom modifier for all of the filters, e.g.
+//
+//	validator.RegisterModifier("lower", func(value reflect.Value, check func(reflect.Value) error) error {
+//		if value.Kind() == reflect.String {
+//			value = reflect.ValueOf(strings.ToLower(value.String()))
+//		}
+//		return check(value)
+//	})
+//
+// The modifier wraps any rule, including the custom ones, and chains with
+// the other modifiers, e.g. Rule{"each:lower:match", `^[a-z]+$`}.
+// Panics if the name is empty, contains a colon, is taken by the built-in
+// modifier, or if fn is nil
+func RegisterModifier(name string, fn ModifierFunc) {
+	std.RegisterModifier(name, fn)
+}
+
+// Registers the custom modifier scoped to the Validator. The modifier overrides
+// the one registered by the package-level RegisterModifier with the same name.
+// Panics if the name is empty, contains a colon, is taken by the built-in
+// modifier, or if fn is nil
+func (v *Validator) RegisterModifier(name string, fn ModifierFunc) {
+	switch {
+	case name == "" || fn == nil:
+		panic("validator: RegisterModifier requires a name and a modifier function")
+
+	case strings.Contains(name, ":"):
+		panic("validator: RegisterModifier requires a name without a colon, given " + name)
+
+	case isBuiltinModifier(name):
+		panic("validator: RegisterModifier cannot override the built-in modifier " + name)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.modifiers[name] = fn
+}
+
+// Looks up the custom modifier of the Validator, then the global one,
+// then the built-in one
+func (v *Validator) modifier(name string) (ModifierFunc, bool) {
+	v.mu.RLock()
+	fn, found := v.modifiers[name]
+	v.mu.RUnlock()
+
+	switch {
+	case found:
+		return fn, true
+
+	case v != std:
+		return std.modifier(name)
+	}
+
+	fn, found = builtinModifiers[name]
+	return fn, found
+}
+
+func isBuiltinModifier(name string) bool {
+	switch name {
+	case "each", "date", "time", "fields":
+		return true
+	}
+
+	_, found := builtinModifiers[name]
+	return found
+}
+
+// Checks the string without the leading and trailing white space
+func modifyTrim(value reflect.Value, check func(reflect.Value) error) error {
+	if value.Kind() == reflect.String {
+		value = reflect.ValueOf(strings.TrimSpace(value.String()))
+	}
+
+	return check(value)
+}
+
+// Checks the absolute value of the number
+func modifyAbs(value reflect.Value, check func(reflect.Value) error) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if num := value.Int(); num < 0 {
+			// the absolute of math.MinInt64 does not fit into int64
+			value = reflect.ValueOf(uint64(-(num + 1)) + 1)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		value = reflect.ValueOf(math.Abs(value.Float()))
+	}
+
+	return check(value)
+}
+
+// Checks each key of the map
+func modifyKeys(value reflect.Value, check func(reflect.Value) error) error {
+	if value.Kind() != reflect.Map {
+		return newError(MsgUnsupportType)
+	}
+
+	keys := value.MapKeys()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	for _, key := range keys {
+		if err := check(key); err != nil {
+			if errors.Is(err, ErrInvalidRule) {
+				return err
+			}
+
+			return fmt.Errorf("key[%v] %w", key.Interface(), err)
+		}
+	}
+
+	return nil
+}
+
+// The hints of the length rules applied to the strings by the len modifier
+var bytesFormats = map[string]string{
+	"min":   MsgMinBytes,
+	"max":   MsgMaxBytes,
+	"eq":    MsgEqBytes,
+	"range": MsgRangeBytes,
+}
+
+// Checks the length of the string in bytes rather than in characters, e.g.
+// "len:max" for the VARCHAR column, or the number of the collection items.
+// The "bytes" modifier is the alias of it
+func modifyLen(value reflect.Value, check func(reflect.Value) error) error {
+	switch value.Kind() {
+	case reflect.String:
+		err := check(reflect.ValueOf(value.Len()))
+
+		// "must be up to 255" of the number becomes "must contain up to 255 bytes"
+		var target *ValidationError
+		if errors.As(err, &target) {
+			if format, found := bytesFormats[target.Code]; found {
+				return newError(format, target.Args...)
+			}
+		}
+
+		return err
+
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		return check(reflect.ValueOf(value.Len()))
+
+	case reflect.Invalid:
+		return newError(MsgInvalidValue)
+	}
+
+	return newError(MsgUnsupportType)
+}
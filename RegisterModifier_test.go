@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestRegisterModifier .
+
+func TestRegisterModifier(t *testing.T) {
+	type Article struct {
+		Title   string            `json:"title"`
+		Tags    []string          `json:"tags"`
+		Balance int               `json:"balance"`
+		Ratio   float64           `json:"ratio"`
+		Options map[string]string `json:"options"`
+		Code    string            `json:"code"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Built-in modifiers`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"trim:min", 3}},
+				{Field: "Tags", Check: Rule{"each:trim:range", Range{2, 8}}},
+				{Field: "Balance", Check: Rule{"abs:max", 100}},
+				{Field: "Ratio", Check: Rule{"abs:max", 0.5}},
+				{Field: "Options", Check: Rule{"keys:match", `^[a-z]+$`}},
+				{Field: "Code", Check: Rule{"len:eq", 4}},
+			}
+
+			hints := filter.Validate(Article{
+				Title:   "  Abc  ",
+				Tags:    []string{" go ", "golang"},
+				Balance: -100,
+				Ratio:   -0.5,
+				Options: map[string]string{"color": "red"},
+				Code:    "ключ"[:4],
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"trim:min", 3}},
+				{Field: "Tags", Check: Rule{"each:trim:range", Range{2, 8}}},
+				{Field: "Balance", Check: Rule{"abs:max", 100}},
+				{Field: "Ratio", Check: Rule{"abs:max", 0.5}},
+				{Field: "Options", Check: Rule{"keys:match", `^[a-z]+$`}},
+				{Field: "Code", Check: Rule{"len:eq", 4}},
+			}
+
+			hints := filter.Validate(Article{
+				Title:   "  Ab  ",
+				Tags:    []string{" go ", " g "},
+				Balance: -101,
+				Ratio:   -0.51,
+				Options: map[string]string{"color": "red", "Size": "XL"},
+				Code:    "ключ",
+			})
+
+			g.Assert(hints).Equal([]string{
+				"title " + fmt.Sprintf(MsgMinStrLen, 3),
+				"tags item[1] " + fmt.Sprintf(MsgRangeStrLen, 2, 8),
+				"balance " + fmt.Sprintf(MsgMax, 100),
+				"ratio " + fmt.Sprintf(MsgMax, 0.5),
+				"options key[Size] " + MsgNotValid,
+				"code " + fmt.Sprintf(MsgEqBytes, 4),
+			})
+		})
+
+		g.It("keeps the details of the wrapped rule", func() {
+			filter := Filter{{Field: "Options", Check: Rule{"keys:min", 3}}}
+
+			errs := filter.Errors(Article{Options: map[string]string{"xl": "1"}})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal("keys:min")
+			g.Assert(errs[0].Value).Equal("xl")
+			g.Assert(errs[0].Message).Equal("key[xl] " + fmt.Sprintf(MsgMinStrLen, 3))
+			g.Assert(errors.Is(errs[0], ErrNotValid)).IsTrue()
+		})
+
+		g.It("takes the absolute of the smallest integer", func() {
+			type Account struct {
+				Balance int64
+			}
+
+			filter := Filter{{Field: "Balance", Check: Rule{"abs:eq", uint64(math.MaxInt64) + 1}}}
+
+			g.Assert(filter.IsValid(Account{Balance: math.MinInt64})).IsTrue()
+		})
+
+		g.It("failure when given an unsupported type value", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"keys:min", 1}},
+				{Field: "Balance", Check: Rule{"len:min", 1}},
+			}
+
+			hints := filter.Validate(Article{Title: "abc", Balance: 1})
+
+			g.Assert(hints).Equal([]string{
+				"title " + MsgUnsupportType,
+				"balance " + MsgUnsupportType,
+			})
+		})
+
+		g.It("failure when the wrapped rule is invalid", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"trim:mni", 1}},
+				{Field: "Options", Check: Rule{"keys:min", nil}},
+			}
+
+			err := filter.Check(Article{Title: "abc", Options: map[string]string{"a": "b"}})
+
+			g.Assert(err.Error()).Equal("title " + MsgInvalidRule + "; options " + MsgInvalidRule)
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue()
+		})
+
+		g.It("builds the filter from the tags with the modifiers", func() {
+			filter, err := FilterFromStruct(struct {
+				Title string   `validate:"trim:min=3"`
+				Tags  []string `validate:"each:trim:range=2..8"`
+				Code  string   `validate:"len:eq=4,trim:match=^\\w{1,4}$"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Title", Check: Rule{"trim:min", 3}},
+				{Field: "Tags", Check: Rule{"each:trim:range", Range{2, 8}}},
+				{Field: "Code", Check: Group{Rule{"len:eq", 4}, Rule{"trim:match", `^\w{1,4}$`}}},
+			})
+		})
+
+		g.It("compiles the filter with the modifiers", func() {
+			compiled, err := Filter{
+				{Field: "Title", Check: Rule{"trim:match", `^\w+$`}},
+				{Field: "Tags", Check: Rule{"each:trim:min", 2}},
+				{Field: "Options", Check: Rule{"keys:match", `^[a-z]+$`}},
+				{Field: "Code", Check: Rule{"len:eq", 4}},
+			}.Compile(Article{})
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Article{Title: " abc ", Tags: []string{" a "}, Code: "abcd"})).Equal([]string{
+				"tags item[0] " + fmt.Sprintf(MsgMinStrLen, 2),
+			})
+
+			items := []Filter{
+				{{Field: "Title", Check: Rule{"trim:mni", 1}}},
+				{{Field: "Balance", Check: Rule{"trim:match", `^\d+$`}}},
+				{{Field: "Options", Check: Rule{"keys:year", 2024}}},
+			}
+
+			for _, filter := range items {
+				_, err := filter.Compile(Article{})
+				g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+			}
+		})
+	})
+
+	g.Describe(`RegisterModifier`, func() {
+		lower := func(value reflect.Value, check func(reflect.Value) error) error {
+			if value.Kind() == reflect.String {
+				value = reflect.ValueOf(strings.ToLower(value.String()))
+			}
+
+			return check(value)
+		}
+
+		RegisterModifier("test-lower", lower)
+
+		g.It("applies the custom modifier to any of the rules", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"test-lower:match", `^[a-z]+$`}},
+				{Field: "Tags", Check: Rule{"each:test-lower:trim:match", `^[a-z]+$`}},
+			}
+
+			g.Assert(filter.IsValid(Article{Title: "ABC", Tags: []string{" Go "}})).IsTrue()
+			g.Assert(filter.Validate(Article{Title: "AB1", Tags: []string{"Go1"}})).Equal([]string{
+				"title " + MsgNotValid,
+				"tags item[0] " + MsgNotValid,
+			})
+		})
+
+		g.It("takes the message of the modifier", func() {
+			v := New()
+			v.RegisterModifier("test-first", func(value reflect.Value, check func(reflect.Value) error) error {
+				if value.Len() == 0 {
+					return errors.New("must have the first item")
+				}
+
+				if err := check(value.Index(0)); err != nil {
+					return fmt.Errorf("first %w", err)
+				}
+
+				return nil
+			})
+
+			filter := Filter{{Field: "Tags", Check: Rule{"test-first:min", 2}}}
+
+			g.Assert(v.Validate(filter, Article{})).Equal([]string{"tags must have the first item"})
+			g.Assert(v.Validate(filter, Article{Tags: []string{"a"}})).Equal([]string{
+				"tags first " + fmt.Sprintf(MsgMinStrLen, 2),
+			})
+			g.Assert(filter.Validate(Article{Tags: []string{"a"}})).Equal([]string{"tags " + MsgInvalidRule})
+		})
+
+		g.It("builds the filter from the tags with the modifier of the instance", func() {
+			type Tagged struct {
+				Tags []string `validate:"test-first:min=2"`
+			}
+
+			v := New()
+			v.RegisterModifier("test-first", func(value reflect.Value, check func(reflect.Value) error) error {
+				return check(value.Index(0))
+			})
+
+			filter, err := v.FilterFromStruct(Tagged{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{{Field: "Tags", Check: Rule{"test-first:min", 2}}})
+
+			_, err = FilterFromStruct(Tagged{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("panics when given the name of the built-in modifier", func() {
+			panics := func(name string, fn ModifierFunc) (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+
+				RegisterModifier(name, fn)
+				return false
+			}
+
+			for _, name := range []string{"", "each", "date", "time", "fields", "trim", "keys", "a:b"} {
+				g.Assert(panics(name, lower)).IsTrue(name)
+			}
+
+			g.Assert(panics("test-nil", nil)).IsTrue()
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Checks the value according to the prototype of the custom rule.
+// Returns the hint message if the value is not valid, e.g. "must be a valid SKU",
+// otherwise an empty string. The value is invalid when the field is missing
+type RuleFunc func(proto, value reflect.Value) string
+
+// Holds the custom rules and modifiers. The ones registered by the package-level
+// RegisterRule and RegisterModifier are available to every Validator and to
+// the Filter methods, while the ones registered by the Validator are scoped to it.
+// A Validator is safe for concurrent use
+type Validator struct {
+	mu        sync.RWMutex
+	rules     map[string]RuleFunc
+	modifiers map[string]ModifierFunc
+
+	// Stop after the first field that is not valid
+	failFast bool
+
+	// Stop after the number of the errors, unlimited unless positive
+	maxErrors int
+
+	// Names the fields in the hints, JSONName when nil
+	names NameFunc
+}
+
+// Configures the Validator, see FailFast, MaxErrors and FieldNames
+type Option func(v *Validator)
+
+// The Validator behind the Filter methods and the package-level registries
+var std = New()
+
+// Creates the Validator with its own set of the custom rules and modifiers, e.g.
+//
+//	v := validator.New(validator.MaxErrors(10))
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		rules:     map[string]RuleFunc{},
+		modifiers: map[string]ModifierFunc{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Stops the validation at the first field that is not valid.
+// The errors of the field are reported in full, followed by the error
+// of the ErrTruncated kind if the filter has the fields left unchecked
+func FailFast() Option {
+	return func(v *Validator) {
+		v.failFast = true
+	}
+}
+
+// Stops the validation once the number of the errors reaches n.
+// The errors beyond n are dropped, and the error of the ErrTruncated kind
+// follows the reported ones if anything was left unchecked or dropped.
+// Zero or a negative n means no limit
+func MaxErrors(n int) Option {
+	return func(v *Validator) {
+		v.maxErrors = n
+	}
+}
+
+// Registers the custom rule for all of the filters, e.g.
+//
+//	validator.RegisterRule("sku", func(proto, value reflect.Value) string {
+//		if !skuRe.MatchString(value.String()) {
+//			return "must be a valid SKU"
+//		}
+//		return ""
+//	})
+//
+// The rule is available with the "each:" modifier as well, e.g. Rule{"each:sku", nil}.
+// Panics if the name is empty, is taken by the built-in rule, or if fn is nil
+func RegisterRule(name string, fn RuleFunc) {
+	std.RegisterRule(name, fn)
+}
+
+// Registers the custom rule scoped to the Validator. The rule overrides
+// the one registered by the package-level RegisterRule with the same name.
+// Panics if the name is empty, is taken by the built-in rule, or if fn is nil
+func (v *Validator) RegisterRule(name string, fn RuleFunc) {
+	switch {
+	case name == "" || fn == nil:
+		panic("validator: RegisterRule requires a name and a rule function")
+
+	case isBuiltinAction(name):
+		panic("validator: RegisterRule cannot override the built-in rule " + name)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.rules[name] = fn
+}
+
+// Looks up the custom rule of the Validator, then the global one
+func (v *Validator) rule(name string) (RuleFunc, bool) {
+	v.mu.RLock()
+	fn, found := v.rules[name]
+	v.mu.RUnlock()
+
+	if !found && v != std {
+		return std.rule(name)
+	}
+
+	return fn, found
+}
+
+func isBuiltinAction(name string) bool {
+	switch name {
+	case NON_ZERO, "range", "min", "max", "eq", "in", "notIn", "match", "year", "utf8",
+		"contains", "notContains", "prefix", "suffix", "icontains", "inotContains", "iprefix", "isuffix",
+		"date:min", "date:max", "date:eq", "time:min", "time:max", "time:eq":
+		return true
+	}
+
+	if isCharClass(name) {
+		return true
+	}
+
+	// reserved for the modifiers
+	return strings.HasPrefix(name, "each:") || strings.HasPrefix(name, "fields:")
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns false or true, respectively. Stops at the first error
+func (v *Validator) IsValid(filter Filter, data any) bool {
+	refValData := reflect.Indirect(reflect.ValueOf(data))
+	if !refValData.IsValid() {
+		return false
+	}
+
+	errs, _ := v.collect(filter, refValData, nil, 1, true)
+	return len(errs) == 0
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns a slice with error hints if at least one field is not valid,
+// otherwise, it will return an empty slice
+func (v *Validator) Validate(filter Filter, data any) []string {
+	return v.Errors(filter, data).Hints()
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns ValidationErrors if at least one field is not valid, otherwise nil
+func (v *Validator) Check(filter Filter, data any) error {
+	if errs := v.Errors(filter, data); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns a slice with the detailed errors if at least one field is not valid,
+// otherwise, it will return an empty slice. The nil data, e.g. the JSON null,
+// results in MsgInvalidValue
+func (v *Validator) Errors(filter Filter, data any) ValidationErrors {
+	refValData := reflect.Indirect(reflect.ValueOf(data))
+	if !refValData.IsValid() {
+		return ValidationErrors{newError(MsgInvalidValue)}
+	}
+
+	return v.errors(filter, refValData, nil)
+}
@@ -0,0 +1,226 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// go test -v -cover -run TestRegisterRule .
+
+func TestRegisterRule(t *testing.T) {
+	type Order struct {
+		Sku   string   `json:"sku"`
+		Skus  []string `json:"skus"`
+		Iban  string   `json:"iban"`
+		Count int      `json:"count"`
+	}
+
+	const msgSku = "must be a valid SKU"
+
+	isSku := func(proto, value reflect.Value) string {
+		if value.Kind() != reflect.String || len(value.String()) != 6 {
+			return msgSku
+		}
+
+		return ""
+	}
+
+	// the prototype is the country code of the IBAN
+	isIban := func(proto, value reflect.Value) string {
+		if !strings.HasPrefix(value.String(), proto.String()) {
+			return "must be an IBAN of " + proto.String()
+		}
+
+		return ""
+	}
+
+	RegisterRule("test:sku", isSku)
+	RegisterRule("test:iban", isIban)
+
+	g := Goblin(t)
+
+	g.Describe(`RegisterRule`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{Field: "Sku", Check: Rule{"test:sku", nil}},
+				{Field: "Skus", Check: Rule{"each:test:sku", nil}},
+				{Field: "Iban", Check: Rule{"test:iban", "UA"}},
+			}
+
+			hints := filter.Validate(Order{
+				Sku:  "ABC123",
+				Skus: []string{"ABC123", "DEF456"},
+				Iban: "UA213223130000026007233566001",
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			filter := Filter{
+				{Field: "Sku", Check: Rule{"test:sku", nil}},
+				{Field: "Skus", Check: Rule{"each:test:sku", nil}},
+				{Field: "Iban", Check: Group{NON_ZERO, Rule{"test:iban", "UA"}}},
+			}
+
+			hints := filter.Validate(Order{
+				Sku:  "ABC",
+				Skus: []string{"ABC123", "DEF"},
+				Iban: "PL61109010140000071219812874",
+			})
+
+			g.Assert(hints).Equal([]string{
+				"sku " + msgSku,
+				"skus item[1] " + msgSku,
+				"iban must be an IBAN of UA",
+			})
+		})
+
+		g.It("describes the failed custom rule", func() {
+			filter := Filter{{Field: "Sku", Check: Rule{"test:sku", nil}}}
+
+			errs := filter.Errors(Order{Sku: "ABC"})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Action).Equal("test:sku")
+			g.Assert(errs[0].Value).Equal("ABC")
+			g.Assert(errors.Is(errs[0], ErrNotValid)).IsTrue()
+		})
+
+		g.It("builds the filter from the tags with the custom rules", func() {
+			filter, err := FilterFromStruct(struct {
+				Sku  string   `validate:"test:sku"`
+				Skus []string `validate:"each:test:sku"`
+				Iban string   `validate:"test:iban=UA"`
+			}{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Sku", Check: Rule{"test:sku", nil}},
+				{Field: "Skus", Check: Rule{"each:test:sku", nil}},
+				{Field: "Iban", Check: Rule{"test:iban", "UA"}},
+			})
+		})
+
+		g.It("compiles the filter with the custom rules", func() {
+			_, err := Filter{
+				{Field: "Sku", Check: Rule{"test:sku", nil}},
+				{Field: "Skus", Check: Rule{"each:test:sku", nil}},
+			}.Compile(Order{})
+
+			g.Assert(err).IsNil()
+
+			_, err = Filter{
+				{Field: "Sku", Check: Rule{"each:test:sku", nil}},
+			}.Compile(Order{})
+
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("panics when given the name of the built-in rule", func() {
+			panics := func(name string, fn RuleFunc) (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+
+				RegisterRule(name, fn)
+				return false
+			}
+
+			for _, name := range []string{"", "min", NON_ZERO, "date:min", "each:sku", "fields:sku"} {
+				g.Assert(panics(name, isSku)).IsTrue(name)
+			}
+
+			g.Assert(panics("test:nil", nil)).IsTrue()
+		})
+	})
+
+	g.Describe(`Validator`, func() {
+		g.It("scopes the custom rule to the instance", func() {
+			v := New()
+			v.RegisterRule("test:even", func(proto, value reflect.Value) string {
+				if value.Int()%2 != 0 {
+					return "must be even"
+				}
+
+				return ""
+			})
+
+			filter := Filter{{Field: "Count", Check: Rule{"test:even", nil}}}
+
+			g.Assert(v.Validate(filter, Order{Count: 3})).Equal([]string{"count must be even"})
+			g.Assert(v.IsValid(filter, Order{Count: 2})).IsTrue()
+			g.Assert(filter.Validate(Order{Count: 3})).Equal([]string{"count " + MsgInvalidRule})
+
+			_, err := filter.Compile(Order{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+
+			compiled, err := v.Compile(filter, Order{})
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Order{Count: 3})).Equal([]string{"count must be even"})
+		})
+
+		g.It("builds the filter from the tags with the custom rule of the instance", func() {
+			type Tagged struct {
+				Count int      `validate:"min=1,test:odd"`
+				Items []string `validate:"each:test:odd"`
+			}
+
+			v := New()
+			v.RegisterRule("test:odd", func(proto, value reflect.Value) string {
+				return ""
+			})
+
+			filter, err := v.FilterFromStruct(&Tagged{})
+
+			g.Assert(err).IsNil()
+			g.Assert(filter).Equal(Filter{
+				{Field: "Count", Check: Group{Rule{"min", 1}, Rule{"test:odd", nil}}},
+				{Field: "Items", Check: Rule{"each:test:odd", nil}},
+			})
+
+			_, err = FilterFromStruct(Tagged{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+
+		g.It("overrides the global custom rule", func() {
+			v := New()
+			v.RegisterRule("test:sku", func(proto, value reflect.Value) string {
+				return ""
+			})
+
+			filter := Filter{{Field: "Sku", Check: Rule{"test:sku", nil}}}
+
+			g.Assert(v.Check(filter, Order{Sku: "ABC"}) == nil).IsTrue()
+			g.Assert(filter.Check(Order{Sku: "ABC"}) == nil).IsFalse()
+		})
+
+		g.It("falls back to the global custom rule", func() {
+			filter := Filter{{Field: "Sku", Check: Rule{"test:sku", nil}}}
+
+			g.Assert(New().Validate(filter, Order{Sku: "ABC"})).Equal([]string{"sku " + msgSku})
+		})
+
+		g.It("applies the custom rules to the sub-filters", func() {
+			type Cart struct {
+				Orders []Order
+			}
+
+			v := New()
+			v.RegisterRule("test:any", func(proto, value reflect.Value) string {
+				return "must be nothing"
+			})
+
+			filter := Filter{{
+				Field: "Orders",
+				Check: Each{{Field: "Sku", Check: Rule{"test:any", nil}}},
+			}}
+
+			g.Assert(v.Validate(filter, Cart{Orders: []Order{{}}})).Equal([]string{
+				"Orders[0].sku must be nothing",
+			})
+		})
+	})
+}
@@ -8,29 +8,30 @@ import (
 // go clean -testcache
 // go test -run Benchmark -bench=. -benchmem .
 // go test -run BenchmarkValidate -bench=. -benchmem .
+// go test -run BenchmarkCompiledValidate -bench=. -benchmem .
 
-func BenchmarkValidate(b *testing.B) {
-	type Article struct {
-		Title   string
-		Age     uint8
-		Phones  [4]string
-		Images  []string
-		Slices  [][]string
-		Maps    []map[int]string
-		Pages   []int
-		Options map[int]string
-		Date    time.Time
-	}
+type benchArticle struct {
+	Title   string
+	Age     uint8
+	Phones  [4]string
+	Images  []string
+	Slices  [][]string
+	Maps    []map[int]string
+	Pages   []int
+	Options map[int]string
+	Date    time.Time
+}
 
-	type Table struct {
-		O string
-		F Filter
-		A Article
-	}
+type benchTable struct {
+	O string
+	F Filter
+	A benchArticle
+}
 
+func benchmarkTable() []benchTable {
 	now := time.Now()
 
-	table := []Table{
+	return []benchTable{
 		// each:range
 
 		{
@@ -39,7 +40,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Pages",
 				Check: Rule{"each:range", []uint8{10, 20}},
 			}},
-			Article{Pages: []int{20}},
+			benchArticle{Pages: []int{20}},
 		},
 		{
 			"Validate(each:range:string)",
@@ -47,7 +48,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Images",
 				Check: Rule{"each:range", []uint8{4, 6}},
 			}},
-			Article{Images: []string{"img1"}},
+			benchArticle{Images: []string{"img1"}},
 		},
 		{
 			"Validate(each:range:slice)",
@@ -55,7 +56,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Slices",
 				Check: Rule{"each:range", []uint8{1, 3}},
 			}},
-			Article{Slices: [][]string{{"img1", "img2", "img3"}}},
+			benchArticle{Slices: [][]string{{"img1", "img2", "img3"}}},
 		},
 		{
 			"Validate(each:range:map)",
@@ -63,7 +64,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Maps",
 				Check: Rule{"each:range", []uint8{1, 3}},
 			}},
-			Article{Maps: []map[int]string{{1: "img1", 2: "img2", 3: "img3"}}},
+			benchArticle{Maps: []map[int]string{{1: "img1", 2: "img2", 3: "img3"}}},
 		},
 
 		// each:match
@@ -74,7 +75,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Images",
 				Check: Rule{"each:match", `img\d`},
 			}},
-			Article{Images: []string{"img1"}},
+			benchArticle{Images: []string{"img1"}},
 		},
 		{
 			"Validate(each:match:map)",
@@ -82,7 +83,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Options",
 				Check: Rule{"each:match", `img\d`},
 			}},
-			Article{Options: map[int]string{1: "img1"}},
+			benchArticle{Options: map[int]string{1: "img1"}},
 		},
 
 		// each:min
@@ -93,7 +94,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Pages",
 				Check: Rule{"each:min", 10},
 			}},
-			Article{Pages: []int{20}},
+			benchArticle{Pages: []int{20}},
 		},
 		{
 			"Validate(each:min:string)",
@@ -101,7 +102,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Images",
 				Check: Rule{"each:min", 4},
 			}},
-			Article{Images: []string{"img1"}},
+			benchArticle{Images: []string{"img1"}},
 		},
 		{
 			"Validate(each:min:slice)",
@@ -109,7 +110,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Slices",
 				Check: Rule{"each:min", 3},
 			}},
-			Article{Slices: [][]string{{"img1", "img2", "img3"}}},
+			benchArticle{Slices: [][]string{{"img1", "img2", "img3"}}},
 		},
 		{
 			"Validate(each:min:map)",
@@ -117,7 +118,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Maps",
 				Check: Rule{"each:min", 3},
 			}},
-			Article{Maps: []map[int]string{{1: "img1", 2: "img2", 3: "img3"}}},
+			benchArticle{Maps: []map[int]string{{1: "img1", 2: "img2", 3: "img3"}}},
 		},
 
 		// range
@@ -128,7 +129,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Age",
 				Check: Range{1, 20},
 			}},
-			Article{Age: 20},
+			benchArticle{Age: 20},
 		},
 		{
 			"Validate(range:string)",
@@ -136,7 +137,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Title",
 				Check: Range{1, 20},
 			}},
-			Article{Title: "Buonasera signorina"},
+			benchArticle{Title: "Buonasera signorina"},
 		},
 		{
 			"Validate(range:slice)",
@@ -144,7 +145,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Images",
 				Check: Range{1, 3},
 			}},
-			Article{Images: []string{"1", "2", "3"}},
+			benchArticle{Images: []string{"1", "2", "3"}},
 		},
 		{
 			"Validate(range:map)",
@@ -152,7 +153,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Options",
 				Check: Range{1, 3},
 			}},
-			Article{Options: map[int]string{1: "1", 2: "2", 3: "3"}},
+			benchArticle{Options: map[int]string{1: "1", 2: "2", 3: "3"}},
 		},
 
 		// match
@@ -161,9 +162,9 @@ func BenchmarkValidate(b *testing.B) {
 			"Validate(match:string)",
 			Filter{{
 				Field: "Title",
-				Check: Range{"match", `Buonasera`},
+				Check: Rule{"match", `Buonasera`},
 			}},
-			Article{Title: "Buonasera"},
+			benchArticle{Title: "Buonasera"},
 		},
 
 		// min
@@ -174,7 +175,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Age",
 				Check: Rule{"min", 10},
 			}},
-			Article{Age: 20},
+			benchArticle{Age: 20},
 		},
 		{
 			"Validate(min:string)",
@@ -182,7 +183,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Title",
 				Check: Rule{"min", 10},
 			}},
-			Article{Title: "Buonasera signorina"},
+			benchArticle{Title: "Buonasera signorina"},
 		},
 		{
 			"Validate(min:slice)",
@@ -190,7 +191,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Images",
 				Check: Rule{"min", 3},
 			}},
-			Article{Images: []string{"1", "2", "3"}},
+			benchArticle{Images: []string{"1", "2", "3"}},
 		},
 		{
 			"Validate(min:map)",
@@ -198,7 +199,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Options",
 				Check: Rule{"min", 3},
 			}},
-			Article{Options: map[int]string{1: "1", 2: "2", 3: "3"}},
+			benchArticle{Options: map[int]string{1: "1", 2: "2", 3: "3"}},
 		},
 
 		// date:min
@@ -209,7 +210,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"date:min", now.Unix()},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 		{
 			"Validate(date:min:string)",
@@ -217,7 +218,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"date:min", now.Format(time.RFC3339)},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 		{
 			"Validate(date:min:time)",
@@ -225,7 +226,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"date:min", now},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 
 		// time:min
@@ -236,7 +237,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"time:min", now.UnixNano()},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 		{
 			"Validate(time:min:string)",
@@ -244,7 +245,7 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"time:min", "1705427897842183962"},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 		{
 			"Validate(time:min:time)",
@@ -252,11 +253,13 @@ func BenchmarkValidate(b *testing.B) {
 				Field: "Date",
 				Check: Rule{"time:min", now},
 			}},
-			Article{Date: now},
+			benchArticle{Date: now},
 		},
 	}
+}
 
-	for _, f := range table {
+func BenchmarkValidate(b *testing.B) {
+	for _, f := range benchmarkTable() {
 		filter := f
 
 		b.Run(filter.O, func(b *testing.B) {
@@ -266,3 +269,48 @@ func BenchmarkValidate(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkCompiledValidate(b *testing.B) {
+	for _, f := range benchmarkTable() {
+		filter := f
+
+		compiled, err := filter.F.Compile(benchArticle{})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(filter.O, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				compiled.Validate(filter.A)
+			}
+		})
+	}
+}
+
+// go test -run BenchmarkIsMin -bench=. -benchmem .
+
+func BenchmarkIsMin(b *testing.B) {
+	type Age uint8
+
+	table := []struct {
+		O string
+		P any
+		V any
+	}{
+		{"IsMin(int:int)", 10, 20},
+		{"IsMin(int8:uint16)", int8(10), uint16(20)},
+		{"IsMin(uint64:int)", uint64(10), -20},
+		{"IsMin(float64:int)", 9.99, 20},
+		{"IsMin(int:Age)", 18, Age(20)},
+	}
+
+	for _, f := range table {
+		item := f
+
+		b.Run(item.O, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				IsMin(item.P, item.V)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -2839,6 +2842,51 @@ func TestValidateMatch(t *testing.T) {
 			g.Assert(len(hints)).Equal(0, hints)
 		})
 
+		g.It("success when given a precompiled mask", func() {
+			filter := Filter{
+				{
+					Field: "Hash",
+					Check: Rule{"match", regexp.MustCompile(`(?i)^[0-9a-f]{32}$`)},
+				},
+			}
+
+			hints := filter.Validate(Article{
+				Hash: "b0fb0c19711bcf3b73f41c909f66bded",
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when the value does not match the precompiled mask", func() {
+			filter := Filter{
+				{
+					Field: "Hash",
+					Check: Rule{"match", regexp.MustCompile(`(?i)^[0-9a-f]{32}$`)},
+				},
+			}
+
+			hints := filter.Validate(Article{Hash: "Z0zZ0z19711zZz3z73z41z909z66zZzZ"})
+
+			g.Assert(len(hints)).Equal(1, hints)
+			g.Assert(hints[0]).Equal(msgInvalidValue)
+		})
+
+		g.It("failure when given a nil precompiled mask", func() {
+			var re *regexp.Regexp
+
+			filter := Filter{
+				{
+					Field: "Hash",
+					Check: Rule{"match", re},
+				},
+			}
+
+			hints := filter.Validate(Article{Hash: "b0fb0c19711bcf3b73f41c909f66bded"})
+
+			g.Assert(len(hints)).Equal(1, hints)
+			g.Assert(hints[0]).Equal(msgInvalidRule)
+		})
+
 		g.It("failure when given an invalid mask", func() {
 			filter := Filter{
 				{
@@ -7883,6 +7931,25 @@ func TestValidateEachMatch(t *testing.T) {
 				g.Assert(len(hints)).Equal(0, hints)
 			})
 
+			g.It("failure when at least 1 value does not match the precompiled mask", func() {
+				filter := Filter{
+					{
+						Field: "Hash",
+						Check: Rule{"each:match", regexp.MustCompile(`(?i)^[0-9a-f]{32}$`)},
+					},
+				}
+
+				hints := filter.Validate(Array{
+					Hash: [2]string{
+						"b0fb0c19711bcf3b73f41c909f66bded",
+						"Z0zZ0z19711zZz3z73z41z909z66zZzZ",
+					},
+				})
+
+				g.Assert(len(hints)).Equal(1, hints)
+				g.Assert(hints[0]).Equal(msgInvalidValue)
+			})
+
 			g.It("failure when at least 1 value does not match", func() {
 				filter := Filter{
 					{
@@ -8567,3 +8634,2127 @@ func TestValidateFieldsMod(t *testing.T) {
 		})
 	})
 }
+
+// go test -v -run TestValidateNested .
+
+func TestValidateNested(t *testing.T) {
+	type Geo struct {
+		Lat float64 `json:"lat"`
+		Lng float64 `json:"lng"`
+	}
+
+	type Address struct {
+		City string `json:"city"`
+		Zip  string
+		Geo  *Geo `json:"geo"`
+	}
+
+	type Article struct {
+		Id      uint     `json:"id"`
+		Address Address  `json:"address"`
+		Billing *Address `json:"billing"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Nested fields`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{
+					Field: "Address.City",
+					Check: Rule{"min", 2},
+				},
+				{
+					Field: "Billing.City",
+					Check: Rule{"min", 2},
+				},
+			}
+
+			hints := filter.Validate(Article{
+				Address: Address{City: "Kyiv"},
+				Billing: &Address{City: "Lviv"},
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			filter := Filter{
+				{
+					Field: "Address.City",
+					Check: Rule{"min", 2},
+				},
+				{
+					Field: "Address.Zip",
+					Check: Rule{"match", `^\d{5}$`},
+				},
+				{
+					Field: "Billing.City",
+					Check: Rule{"min", 2},
+				},
+			}
+
+			hints := filter.Validate(&Article{
+				Address: Address{City: "K", Zip: "0100"},
+				Billing: &Address{City: "L"},
+			})
+
+			g.Assert(len(hints)).Equal(3, hints)
+			g.Assert(hints[0]).Equal("address.city must contain at least 2 characters")
+			g.Assert(hints[1]).Equal("address.Zip " + MsgNotValid)
+			g.Assert(hints[2]).Equal("billing.city must contain at least 2 characters")
+		})
+
+		g.It("failure with the Go path of the field", func() {
+			filter := Filter{
+				{
+					Field: "Billing.City",
+					Check: NON_ZERO,
+				},
+			}
+
+			errs := filter.Errors(Article{Billing: &Address{}})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Field).Equal("billing.city")
+			g.Assert(errs[0].Path).Equal("Billing.City")
+		})
+
+		g.It("failure when a pointer on the path is nil", func() {
+			filter := Filter{
+				{
+					Field: "Billing.City",
+					Check: NON_ZERO,
+				},
+				{
+					Field: "Address.Geo.Lat",
+					Check: Rule{"min", 1},
+				},
+			}
+
+			hints := filter.Validate(Article{})
+
+			g.Assert(len(hints)).Equal(2, hints)
+			g.Assert(hints[0]).Equal("billing.city " + MsgEmpty)
+			g.Assert(hints[1]).Equal("address.geo.lat " + MsgInvalidValue)
+		})
+
+		g.It("success when the field is optional and a pointer on the path is nil", func() {
+			filter := Filter{
+				{
+					Field:    "Billing.City",
+					Check:    Rule{"min", 2},
+					Optional: true,
+				},
+			}
+
+			hints := filter.Validate(Article{})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given an unknown nested field", func() {
+			filter := Filter{
+				{
+					Field: "Address.Street",
+					Check: NON_ZERO,
+				},
+				{
+					Field: "Id.Value",
+					Check: NON_ZERO,
+				},
+			}
+
+			hints := filter.Validate(Article{})
+
+			g.Assert(len(hints)).Equal(2, hints)
+			g.Assert(hints[0]).Equal(MsgInvalidRule)
+			g.Assert(hints[1]).Equal(MsgInvalidRule)
+		})
+	})
+}
+
+// go test -v -run TestValidateEachFilter .
+
+func TestValidateEachFilter(t *testing.T) {
+	type LineItem struct {
+		Sku string `json:"sku"`
+		Qty uint   `json:"qty"`
+	}
+
+	type Contact struct {
+		Phone string `json:"phone"`
+	}
+
+	type Order struct {
+		Items    []LineItem         `json:"items"`
+		Pointers []*LineItem        `json:"pointers"`
+		Contacts map[string]Contact `json:"contacts"`
+		Nested   [][]string         `json:"nested"`
+		Title    string             `json:"title"`
+	}
+
+	lineItemFilter := Filter{
+		{
+			Field: "Sku",
+			Check: Rule{"min", 3},
+		},
+		{
+			Field: "Qty",
+			Check: Rule{"min", 1},
+		},
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Each sub-filter`, func() {
+		g.It("success when given valid values", func() {
+			filter := Filter{
+				{
+					Field: "Items",
+					Check: Each(lineItemFilter),
+				},
+			}
+
+			hints := filter.Validate(Order{
+				Items: []LineItem{
+					{Sku: "abc", Qty: 1},
+					{Sku: "abcd", Qty: 2},
+				},
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure with the indexed paths of the slice items", func() {
+			filter := Filter{
+				{
+					Field: "Items",
+					Check: Each(lineItemFilter),
+				},
+			}
+
+			hints := filter.Validate(Order{
+				Items: []LineItem{
+					{Sku: "abc", Qty: 1},
+					{Sku: "ab", Qty: 1},
+					{Sku: "abc", Qty: 0},
+					{Sku: "a"},
+				},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"items[1].sku must contain at least 3 characters",
+				"items[2].qty must be at least 1",
+				"items[3].sku must contain at least 3 characters",
+				"items[3].qty must be at least 1",
+			})
+		})
+
+		g.It("failure with the Go paths of the slice items", func() {
+			filter := Filter{
+				{
+					Field: "Items",
+					Check: Each(lineItemFilter),
+				},
+			}
+
+			errs := filter.Errors(Order{
+				Items: []LineItem{{Sku: "a", Qty: 1}},
+			})
+
+			g.Assert(len(errs)).Equal(1, errs)
+			g.Assert(errs[0].Field).Equal("items[0].sku")
+			g.Assert(errs[0].Path).Equal("Items[0].Sku")
+			g.Assert(errs[0].Value).Equal("a")
+		})
+
+		g.It("failure with the indexed paths of the pointers", func() {
+			filter := Filter{
+				{
+					Field: "Pointers",
+					Check: Each(lineItemFilter),
+				},
+			}
+
+			hints := filter.Validate(Order{
+				Pointers: []*LineItem{{Sku: "ab", Qty: 1}, nil},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"pointers[0].sku must contain at least 3 characters",
+				"pointers[1] " + MsgInvalidValue,
+			})
+		})
+
+		g.It("failure with the keyed paths of the map items", func() {
+			filter := Filter{
+				{
+					Field: "Contacts",
+					Check: Each{
+						{
+							Field: "Phone",
+							Check: Rule{"match", `^\+38\d{10}$`},
+						},
+					},
+				},
+			}
+
+			hints := filter.Validate(Order{
+				Contacts: map[string]Contact{
+					"work": {Phone: "+380001234567"},
+					"home": {Phone: "0001234567"},
+					"cell": {Phone: "1234567"},
+				},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"contacts[cell].phone " + MsgNotValid,
+				"contacts[home].phone " + MsgNotValid,
+			})
+		})
+
+		g.It("failure with the recursive paths", func() {
+			type Shipment struct {
+				Orders []Order `json:"orders"`
+			}
+
+			filter := Filter{
+				{
+					Field: "Orders",
+					Check: Group{
+						NON_ZERO,
+						Each{
+							{
+								Field: "Items",
+								Check: Each(lineItemFilter),
+							},
+						},
+					},
+				},
+			}
+
+			hints := filter.Validate(Shipment{
+				Orders: []Order{
+					{Items: []LineItem{{Sku: "abc", Qty: 1}}},
+					{Items: []LineItem{{Sku: "abc", Qty: 1}, {Sku: "ab", Qty: 1}}},
+				},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"orders[1].items[1].sku must contain at least 3 characters",
+			})
+		})
+
+		g.It("failure when the items are not structures", func() {
+			filter := Filter{
+				{
+					Field: "Nested",
+					Check: Each(lineItemFilter),
+				},
+				{
+					Field: "Title",
+					Check: Each(lineItemFilter),
+				},
+			}
+
+			hints := filter.Validate(Order{
+				Nested: [][]string{{}},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"nested[0] " + MsgUnsupportType,
+				"title " + MsgUnsupportType,
+			})
+		})
+	})
+}
+
+// go test -v -run TestValidateMap .
+
+func TestValidateMap(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe(`Map payloads`, func() {
+		filter := Filter{
+			{
+				Field: "title",
+				Check: Group{NON_ZERO, Rule{"min", 3}},
+			},
+			{
+				Field: "age",
+				Check: Range{18, 99},
+			},
+			{
+				Field: "address.city",
+				Check: Rule{"min", 2},
+			},
+			{
+				Field:    "phone",
+				Check:    Rule{"match", `^\+38\d{10}$`},
+				Optional: true,
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(map[string]any{
+				"title": "Yellow submarine",
+				"age":   20,
+				"address": map[string]any{
+					"city": "Kyiv",
+				},
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			hints := filter.Validate(map[string]any{
+				"title": "Ye",
+				"age":   16,
+				"address": map[string]any{
+					"city": "K",
+				},
+				"phone": "0001234567",
+			})
+
+			g.Assert(hints).Equal([]string{
+				"title must contain at least 3 characters",
+				"age must be in the range 18..99",
+				"address.city must contain at least 2 characters",
+				"phone " + MsgNotValid,
+			})
+		})
+
+		g.It("failure when missing keys", func() {
+			hints := filter.Validate(map[string]any{
+				"title": nil,
+			})
+
+			g.Assert(hints).Equal([]string{
+				"title " + MsgEmpty,
+				"age " + MsgInvalidValue,
+				"address.city " + MsgInvalidValue,
+			})
+		})
+
+		g.It("failure when given a typed map", func() {
+			filter := Filter{
+				{
+					Field: "title",
+					Check: Rule{"max", 3},
+				},
+			}
+
+			hints := filter.Validate(map[string]string{
+				"title": "Yellow submarine",
+			})
+
+			g.Assert(hints).Equal([]string{
+				"title must contain up to 3 characters",
+			})
+		})
+
+		g.It("failure when given a map nested in the structure", func() {
+			type Article struct {
+				Meta map[string]any `json:"meta"`
+			}
+
+			filter := Filter{
+				{
+					Field: "Meta.author",
+					Check: NON_ZERO,
+				},
+			}
+
+			hints := filter.Validate(Article{
+				Meta: map[string]any{"author": ""},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"meta.author " + MsgEmpty,
+			})
+		})
+
+		g.It("failure when given the items of sub-filter", func() {
+			filter := Filter{
+				{
+					Field: "items",
+					Check: Each{
+						{
+							Field: "sku",
+							Check: Rule{"min", 3},
+						},
+					},
+				},
+			}
+
+			hints := filter.Validate(map[string]any{
+				"items": []any{
+					map[string]any{"sku": "abc"},
+					map[string]any{"sku": "ab"},
+				},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"items[1].sku must contain at least 3 characters",
+			})
+		})
+
+		g.It("failure when given the nil payload", func() {
+			var payload any
+			var article *struct{ Title string }
+
+			g.Assert(filter.Validate(payload)).Equal([]string{MsgInvalidValue})
+			g.Assert(filter.Validate(article)).Equal([]string{MsgInvalidValue})
+			g.Assert(filter.IsValid(payload)).IsFalse()
+		})
+	})
+}
+
+func TestValidateFloat(t *testing.T) {
+	type Product struct {
+		Price   float64            `json:"price"`
+		Rating  float32            `json:"rating"`
+		Stock   int                `json:"stock"`
+		Prices  []float64          `json:"prices"`
+		Ratings map[string]float32 `json:"ratings"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Floating-point values`, func() {
+		filter := Filter{
+			{
+				Field: "Price",
+				Check: Group{Rule{"min", 0.01}, Rule{"max", 1000}},
+			},
+			{
+				Field: "Rating",
+				Check: Range{1, 5},
+			},
+			{
+				Field: "Stock",
+				Check: Rule{"max", 99.5},
+			},
+			{
+				Field: "Prices",
+				Check: Rule{"each:range", Range{0.5, 10}},
+			},
+			{
+				Field: "Ratings",
+				Check: Rule{"each:eq", 5},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Product{
+				Price:   9.99,
+				Rating:  4.5,
+				Stock:   99,
+				Prices:  []float64{0.5, 10},
+				Ratings: map[string]float32{"john": 5},
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			hints := filter.Validate(Product{
+				Price:   0.001,
+				Rating:  5.01,
+				Stock:   100,
+				Prices:  []float64{0.5, 10.5},
+				Ratings: map[string]float32{"john": 4.99},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"price " + fmt.Sprintf(MsgMin, 0.01),
+				"rating " + fmt.Sprintf(MsgRange, 1, 5),
+				"stock " + fmt.Sprintf(MsgMax, 99.5),
+				"prices item[1] " + fmt.Sprintf(MsgRange, 0.5, 10),
+				"ratings item[john] " + fmt.Sprintf(MsgEq, 5),
+			})
+		})
+
+		g.It("failure when given NaN", func() {
+			hints := filter.Validate(Product{
+				Price:   math.NaN(),
+				Rating:  float32(math.NaN()),
+				Stock:   1,
+				Prices:  []float64{math.NaN()},
+				Ratings: map[string]float32{},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"price " + fmt.Sprintf(MsgMin, 0.01),
+				"rating " + fmt.Sprintf(MsgRange, 1, 5),
+				"prices item[0] " + fmt.Sprintf(MsgRange, 0.5, 10),
+			})
+		})
+
+		g.It("failure when given infinity", func() {
+			hints := filter.Validate(Product{
+				Price:  math.Inf(1),
+				Rating: float32(math.Inf(-1)),
+				Stock:  1,
+			})
+
+			g.Assert(hints).Equal([]string{
+				"price " + fmt.Sprintf(MsgMax, 1000),
+				"rating " + fmt.Sprintf(MsgRange, 1, 5),
+			})
+		})
+	})
+}
+
+func TestValidateDefinedTypes(t *testing.T) {
+	type (
+		Age    uint8
+		Rating float32
+	)
+
+	type Person struct {
+		Age     Age      `json:"age"`
+		Rating  Rating   `json:"rating"`
+		Ratings []Rating `json:"ratings"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Defined numeric types`, func() {
+		filter := Filter{
+			{
+				Field: "Age",
+				Check: Group{Rule{"min", 18}, Rule{"max", Age(99)}},
+			},
+			{
+				Field: "Rating",
+				Check: Range{1, 5},
+			},
+			{
+				Field: "Ratings",
+				Check: Rule{"each:eq", 5},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Person{Age: 18, Rating: 4.5, Ratings: []Rating{5}})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			hints := filter.Validate(Person{Age: 100, Rating: 0.5, Ratings: []Rating{4}})
+
+			g.Assert(hints).Equal([]string{
+				"age " + fmt.Sprintf(MsgMax, 99),
+				"rating " + fmt.Sprintf(MsgRange, 1, 5),
+				"ratings item[0] " + fmt.Sprintf(MsgEq, 5),
+			})
+		})
+	})
+}
+
+func TestValidateMessage(t *testing.T) {
+	type LineItem struct {
+		Sku string `json:"sku"`
+	}
+
+	type Article struct {
+		Phone string     `json:"phone"`
+		Title string     `json:"title"`
+		Age   int        `json:"age"`
+		Items []LineItem `json:"items"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Custom messages`, func() {
+		g.It("renders the template in place of the default hint", func() {
+			filter := Filter{
+				{
+					Field:   "Phone",
+					Check:   Rule{"match", `^\+38\d{10}$`},
+					Message: "Please enter a phone number in +38XXXXXXXXXX format",
+				},
+			}
+
+			err := filter.Check(Article{Phone: "0501234567"})
+
+			g.Assert(err.Error()).Equal("Please enter a phone number in +38XXXXXXXXXX format")
+			g.Assert(errors.Is(err, ErrNotValid)).IsTrue()
+
+			var target *ValidationError
+			g.Assert(errors.As(err, &target)).IsTrue()
+			g.Assert(target.Field).Equal("phone")
+		})
+
+		g.It("renders the placeholders", func() {
+			filter := Filter{
+				{
+					Field:   "Age",
+					Check:   Range{18, 99},
+					Message: "{field} is {value}, expected {min} to {max}",
+				},
+				{
+					Field:   "Title",
+					Check:   Rule{"min", 3},
+					Message: "{field} {value} is shorter than {proto}",
+				},
+			}
+
+			hints := filter.Validate(Article{Age: 16, Title: "ab"})
+
+			g.Assert(hints).Equal([]string{
+				"age is 16, expected 18 to 99",
+				"title ab is shorter than 3",
+			})
+		})
+
+		g.It("renders the template of the failed action in the group", func() {
+			filter := Filter{
+				{
+					Field: "Title",
+					Check: Group{NON_ZERO, Rule{"min", 3}, Rule{"match", `^[A-Z]`}},
+					Messages: map[string]string{
+						NON_ZERO: "Please enter the {field}",
+						"min":    "The {field} must be at least {proto} characters long",
+					},
+					Message: "The {field} must start with a capital letter",
+				},
+			}
+
+			g.Assert(filter.Validate(Article{})).Equal([]string{"Please enter the title"})
+			g.Assert(filter.Validate(Article{Title: "ab"})).Equal([]string{
+				"The title must be at least 3 characters long",
+			})
+			g.Assert(filter.Validate(Article{Title: "abc"})).Equal([]string{
+				"The title must start with a capital letter",
+			})
+		})
+
+		g.It("renders the template of the body rule", func() {
+			filter := Filter{
+				{Field: "Title", Check: NON_ZERO},
+				{Check: Rule{"fields:min", 1}, Message: "Please fill in the form"},
+			}
+
+			g.Assert(filter.Validate(Article{})).Equal([]string{
+				"title " + MsgEmpty,
+				"Please fill in the form",
+			})
+		})
+
+		g.It("renders the templates of the sub-filters", func() {
+			filter := Filter{
+				{
+					Field: "Items",
+					Check: Each{{
+						Field:   "Sku",
+						Check:   Rule{"match", `^[A-Z]{3}$`},
+						Message: "SKU {value} is not valid",
+					}},
+					Message: "Please check the {field}",
+				},
+			}
+
+			g.Assert(filter.Validate(Article{Items: []LineItem{{Sku: "abc"}}})).Equal([]string{
+				"SKU abc is not valid",
+			})
+		})
+
+		g.It("keeps the hint of the invalid rule", func() {
+			filter := Filter{
+				{Field: "Title", Check: Rule{"mni", 3}, Message: "Please enter the {field}"},
+			}
+
+			g.Assert(filter.Validate(Article{})).Equal([]string{"title " + MsgInvalidRule})
+		})
+
+		g.It("keeps the rendered template when localized", func() {
+			filter := Filter{
+				{Field: "Title", Check: NON_ZERO, Message: "Please enter the {field}"},
+				{Field: "Phone", Check: NON_ZERO},
+			}
+
+			g.Assert(filter.Errors(Article{}).Localize("uk").Hints()).Equal([]string{
+				"Please enter the title",
+				"phone не заповнено",
+			})
+		})
+	})
+}
+
+func TestValidateAllOf(t *testing.T) {
+	type Account struct {
+		Password string   `json:"password"`
+		Tags     []string `json:"tags"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rule AllOf`, func() {
+		filter := Filter{
+			{
+				Field: "Password",
+				Check: AllOf{
+					Rule{"min", 8},
+					Rule{"match", `\d`},
+					Rule{"match", `[A-Z]`},
+				},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Account{Password: "Secret123"})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("reports every failed rule", func() {
+			hints := filter.Validate(Account{Password: "secret"})
+
+			g.Assert(hints).Equal([]string{
+				"password " + fmt.Sprintf(MsgMinStrLen, 8),
+				"password " + MsgNotValid,
+				"password " + MsgNotValid,
+			})
+		})
+
+		g.It("reports the failed rules of the nested group once", func() {
+			filter := Filter{
+				{
+					Field: "Tags",
+					Check: AllOf{
+						Group{NON_ZERO, Rule{"min", 2}},
+						Rule{"each:min", 3},
+					},
+				},
+			}
+
+			hints := filter.Validate(Account{Tags: []string{"go"}})
+
+			g.Assert(hints).Equal([]string{
+				"tags " + fmt.Sprintf(MsgMinSetLen, 2),
+				"tags item[0] " + fmt.Sprintf(MsgMinStrLen, 3),
+			})
+		})
+
+		g.It("renders the templates of each failed rule", func() {
+			filter := Filter{
+				{
+					Field: "Password",
+					Check: AllOf{Rule{"min", 8}, Rule{"match", `\d`}},
+					Messages: map[string]string{
+						"min":   "Use at least {proto} characters",
+						"match": "Use at least one digit",
+					},
+				},
+			}
+
+			g.Assert(filter.Validate(Account{Password: "secret"})).Equal([]string{
+				"Use at least 8 characters",
+				"Use at least one digit",
+			})
+		})
+
+		g.It("compiles the rules", func() {
+			compiled, err := filter.Compile(Account{})
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Account{Password: "secret"})).Equal(filter.Validate(Account{Password: "secret"}))
+
+			_, err = Filter{{Field: "Password", Check: AllOf{Rule{"mni", 8}}}}.Compile(Account{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+	})
+}
+
+func TestValidateOptions(t *testing.T) {
+	type Row struct {
+		Id    int    `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	g := Goblin(t)
+
+	filter := Filter{
+		{Field: "Id", Check: Rule{"min", 1}},
+		{Field: "Name", Check: AllOf{NON_ZERO, Rule{"min", 2}}},
+		{Field: "Email", Check: Rule{"match", `@`}},
+	}
+
+	g.Describe(`Validator options`, func() {
+		g.It("reports every error by default", func() {
+			hints := New().Validate(filter, Row{})
+
+			g.Assert(len(hints)).Equal(4, hints)
+		})
+
+		g.It("stops at the first failed field", func() {
+			v := New(FailFast())
+
+			g.Assert(v.Validate(filter, Row{Id: 1})).Equal([]string{
+				"name " + MsgEmpty,
+				"name " + fmt.Sprintf(MsgMinStrLen, 2),
+				MsgTruncated,
+			})
+		})
+
+		g.It("does not truncate when the last field fails", func() {
+			v := New(FailFast())
+
+			g.Assert(v.Validate(filter, Row{Id: 1, Name: "Jo"})).Equal([]string{
+				"email " + MsgNotValid,
+			})
+		})
+
+		g.It("stops after the number of errors", func() {
+			v := New(MaxErrors(1))
+
+			g.Assert(v.Validate(filter, Row{Id: 1})).Equal([]string{
+				"name " + MsgEmpty,
+				MsgTruncated,
+			})
+
+			v = New(MaxErrors(3))
+
+			g.Assert(v.Validate(filter, Row{})).Equal([]string{
+				"id " + fmt.Sprintf(MsgMin, 1),
+				"name " + MsgEmpty,
+				"name " + fmt.Sprintf(MsgMinStrLen, 2),
+				MsgTruncated,
+			})
+
+			g.Assert(len(New(MaxErrors(4)).Validate(filter, Row{}))).Equal(4)
+			g.Assert(len(New(MaxErrors(0)).Validate(filter, Row{}))).Equal(4)
+		})
+
+		g.It("marks the truncated errors", func() {
+			errs := New(MaxErrors(1)).Errors(filter, Row{})
+
+			g.Assert(errors.Is(errs, ErrTruncated)).IsTrue()
+			g.Assert(errors.Is(New().Errors(filter, Row{}), ErrTruncated)).IsFalse()
+			g.Assert(errs.Localize("uk")[1].Message).Equal("перевірку зупинено, решту помилок не наведено")
+		})
+
+		g.It("does not limit the sub-filters", func() {
+			type Import struct {
+				Rows []Row `json:"rows"`
+			}
+
+			v := New(MaxErrors(1))
+			filter := Filter{{Field: "Rows", Check: Each(filter)}}
+
+			g.Assert(v.Validate(filter, Import{Rows: []Row{{Id: 1}, {Id: 1, Name: "Jo"}}})).Equal([]string{
+				"rows[0].name " + MsgEmpty,
+				MsgTruncated,
+			})
+		})
+
+		g.It("applies to the compiled filters", func() {
+			compiled, err := New(FailFast()).Compile(filter, Row{})
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Row{})).Equal([]string{
+				"id " + fmt.Sprintf(MsgMin, 1),
+				MsgTruncated,
+			})
+		})
+	})
+}
+
+func TestValidateCombinators(t *testing.T) {
+	type Contact struct {
+		Login   string `json:"login"`
+		Contact string `json:"contact"`
+		Code    string `json:"code"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rule AnyOf`, func() {
+		filter := Filter{
+			{
+				Field: "Contact",
+				Check: AnyOf{
+					Rule{"match", `^\d{10}$`},
+					Group{Rule{"min", 5}, Rule{"match", `^\S+@\S+$`}},
+				},
+			},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(len(filter.Validate(Contact{Contact: "0501234567"}))).Equal(0)
+			g.Assert(len(filter.Validate(Contact{Contact: "me@example.com"}))).Equal(0)
+		})
+
+		g.It("joins the hints of the failed rules", func() {
+			g.Assert(filter.Validate(Contact{Contact: "me"})).Equal([]string{
+				"contact " + MsgNotValid + " or " + fmt.Sprintf(MsgMinStrLen, 5),
+			})
+
+			g.Assert(filter.Validate(Contact{Contact: "me@"})).Equal([]string{
+				"contact " + MsgNotValid + " or " + fmt.Sprintf(MsgMinStrLen, 5),
+			})
+
+			g.Assert(filter.Validate(Contact{Contact: "me.example.com"})).Equal([]string{
+				"contact " + MsgNotValid,
+			})
+		})
+
+		g.It("joins the same hints once", func() {
+			filter := Filter{
+				{Field: "Contact", Check: AnyOf{Rule{"match", `^\+?\d{10,12}$`}, Rule{"match", `^\S+@\S+$`}}},
+			}
+
+			g.Assert(filter.Validate(Contact{Contact: "me"})).Equal([]string{"contact " + MsgNotValid})
+		})
+
+		g.It("localizes the joined hints", func() {
+			errs := filter.Errors(Contact{Contact: "me"})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{
+				"contact має недійсне значення або має містити щонайменше 5 символів",
+			})
+		})
+
+		g.It("passes the empty value explicitly", func() {
+			filter := Filter{
+				{Field: "Code", Check: AnyOf{Not{NON_ZERO}, Rule{"match", `^\d{4}$`}}},
+			}
+
+			g.Assert(len(filter.Validate(Contact{}))).Equal(0)
+			g.Assert(len(filter.Validate(Contact{Code: "1234"}))).Equal(0)
+			g.Assert(filter.Validate(Contact{Code: "12"})).Equal([]string{
+				"code " + MsgNotValid,
+			})
+		})
+
+		g.It("does not depend on the order of the rules", func() {
+			type Account struct {
+				Phone *string `json:"phone"`
+			}
+
+			phone, short := "0501234567", "050"
+
+			for _, rules := range []AnyOf{
+				{Not{NON_ZERO}, Rule{"match", `^\d{10}$`}},
+				{Rule{"match", `^\d{10}$`}, Not{NON_ZERO}},
+			} {
+				filter := Filter{{Field: "Phone", Check: rules}}
+
+				g.Assert(len(filter.Validate(Account{}))).Equal(0, rules)
+				g.Assert(len(filter.Validate(Account{Phone: &phone}))).Equal(0, rules)
+				g.Assert(filter.Validate(Account{Phone: &short})).Equal([]string{"phone " + MsgNotValid}, rules)
+			}
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			g.Assert(Filter{{Field: "Code", Check: AnyOf{Rule{"mni", 4}, Not{NON_ZERO}}}}.Validate(Contact{})).Equal([]string{
+				"code " + MsgInvalidRule,
+			})
+
+			filter := Filter{
+				{Field: "Code", Check: AnyOf{Not{NON_ZERO}, Rule{"mni", 4}}},
+			}
+
+			g.Assert(filter.Validate(Contact{Code: "12"})).Equal([]string{"code " + MsgInvalidRule})
+			g.Assert(Filter{{Field: "Code", Check: AnyOf{}}}.Validate(Contact{})).Equal([]string{
+				"code " + MsgInvalidRule,
+			})
+		})
+	})
+
+	g.Describe(`Rule OneOf`, func() {
+		filter := Filter{
+			{
+				Field: "Code",
+				Check: OneOf{Rule{"match", `^\d+$`}, Rule{"min", 4}},
+			},
+		}
+
+		g.It("success when exactly one rule passes", func() {
+			g.Assert(len(filter.Validate(Contact{Code: "12"}))).Equal(0)
+			g.Assert(len(filter.Validate(Contact{Code: "abcd"}))).Equal(0)
+		})
+
+		g.It("failure when several rules pass", func() {
+			errs := filter.Errors(Contact{Code: "1234"})
+
+			g.Assert(errs.Hints()).Equal([]string{"code " + MsgOneOf})
+			g.Assert(errs[0].Action).Equal("OneOf")
+		})
+
+		g.It("failure when no rule passes", func() {
+			g.Assert(filter.Validate(Contact{Code: "ab"})).Equal([]string{
+				"code " + MsgNotValid + " or " + fmt.Sprintf(MsgMinStrLen, 4),
+			})
+		})
+	})
+
+	g.Describe(`Rule Not`, func() {
+		filter := Filter{
+			{
+				Field: "Login",
+				Check: Group{NON_ZERO, Not{Rule{"match", "^admin"}}},
+			},
+		}
+
+		g.It("success when the rule does not pass", func() {
+			g.Assert(len(filter.Validate(Contact{Login: "user"}))).Equal(0)
+		})
+
+		g.It("failure when the rule passes", func() {
+			errs := filter.Errors(Contact{Login: "administrator"})
+
+			g.Assert(errs.Hints()).Equal([]string{"login " + fmt.Sprintf(MsgNotMatch, "^admin")})
+			g.Assert(errs[0].Action).Equal("Not")
+			g.Assert(errs[0].Value).Equal("administrator")
+		})
+
+		g.It("negates the hint of the rule", func() {
+			type Order struct {
+				Status string `json:"status"`
+				Sku    string `json:"sku"`
+				Qty    int    `json:"qty"`
+			}
+
+			items := []struct {
+				rule any
+				hint string
+			}{
+				{Rule{"in", []string{"draft", "deleted"}}, "status must not be one of draft, deleted"},
+				{Rule{"notIn", []string{"paid", "sent"}}, "status must be one of paid, sent"},
+				{Rule{"icontains", "RAF"}, `status must not contain "RAF"`},
+				{Rule{"prefix", "dr"}, `status must not start with "dr"`},
+				{Rule{"suffix", "ft"}, `status must not end with "ft"`},
+				{Rule{"min", 3}, "status must not pass the min rule"},
+				{Range{1, 8}, "status must not pass the range rule"},
+				{Group{NON_ZERO, Rule{"max", 8}}, "status " + MsgNotValid},
+			}
+
+			for _, item := range items {
+				hints := Filter{{Field: "Status", Check: Not{item.rule}}}.Validate(Order{Status: "draft"})
+
+				g.Assert(hints).Equal([]string{item.hint}, item.rule)
+			}
+		})
+
+		g.It("localizes the negated hint", func() {
+			errs := Filter{{Field: "Login", Check: Not{Rule{"prefix", "admin"}}}}.Errors(Contact{Login: "admin"})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{`login не має починатися з "admin"`})
+		})
+
+		g.It("failure when the rule does not apply to the value", func() {
+			type Account struct {
+				Id    int     `json:"id"`
+				Login *string `json:"login"`
+			}
+
+			g.Assert(Filter{{Field: "Id", Check: Not{Rule{"prefix", "admin"}}}}.Validate(Account{})).Equal([]string{
+				"id " + MsgUnsupportType,
+			})
+
+			g.Assert(Filter{{Field: "Login", Check: Not{Rule{"match", "^admin"}}}}.Validate(Account{})).Equal([]string{
+				"login " + MsgInvalidValue,
+			})
+
+			g.Assert(Filter{{Field: "Id", Check: AnyOf{Rule{"prefix", "1"}, Rule{"min", 100}}}}.Validate(Account{})).Equal([]string{
+				"id " + MsgUnsupportType,
+			})
+
+			g.Assert(Filter{{Field: "Login", Check: OneOf{Rule{"match", `^\d+$`}, Rule{"min", 4}}}}.Validate(Account{})).Equal([]string{
+				"login " + MsgInvalidValue,
+			})
+		})
+
+		g.It("renders the template of the combinator", func() {
+			filter := Filter{
+				{
+					Field:    "Login",
+					Check:    Not{Rule{"match", "^admin"}},
+					Messages: map[string]string{"Not": "The {field} {value} is reserved"},
+				},
+			}
+
+			g.Assert(filter.Validate(Contact{Login: "admin"})).Equal([]string{
+				"The login admin is reserved",
+			})
+		})
+
+		g.It("nests the combinators", func() {
+			filter := Filter{
+				{
+					Field: "Login",
+					Check: Not{AnyOf{Rule{"match", "^admin"}, Rule{"match", "^root"}}},
+				},
+			}
+
+			g.Assert(len(filter.Validate(Contact{Login: "user"}))).Equal(0)
+			g.Assert(len(filter.Validate(Contact{Login: "root"}))).Equal(1)
+		})
+
+		g.It("compiles the combinators", func() {
+			filter := Filter{
+				{Field: "Login", Check: Not{Rule{"match", "^admin"}}},
+				{Field: "Code", Check: OneOf{Rule{"match", `^\d+$`}, Rule{"min", 4}}},
+				{Field: "Contact", Check: &AnyOf{Rule{"match", `^\d{10}$`}, Rule{"match", `@`}}},
+			}
+
+			compiled, err := filter.Compile(Contact{})
+			data := Contact{Login: "admin", Code: "1234", Contact: "me"}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(3)
+		})
+	})
+}
+
+func TestValidateConditions(t *testing.T) {
+	type Status string
+
+	type Customer struct {
+		CustomerType uint8             `json:"customerType"`
+		CompanyName  string            `json:"companyName"`
+		Email        string            `json:"email"`
+		Phone        string            `json:"phone"`
+		Status       Status            `json:"status"`
+		Extra        map[string]any    `json:"extra"`
+		Contacts     []Customer        `json:"contacts"`
+		Tags         map[string]string `json:"tags"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Conditions`, func() {
+		filter := Filter{
+			{
+				Field: "CompanyName",
+				Check: NON_ZERO,
+				When:  FieldEq("CustomerType", 2),
+			},
+			{
+				Field: "Phone",
+				Check: Rule{"match", `^\+38\d{10}$`},
+				When:  FieldEmpty("Email"),
+			},
+		}
+
+		g.It("checks the field when the condition holds", func() {
+			g.Assert(filter.Validate(Customer{CustomerType: 2, Email: "me@example.com"})).Equal([]string{
+				"companyName " + MsgEmpty,
+			})
+
+			g.Assert(filter.Validate(Customer{CustomerType: 1})).Equal([]string{
+				"phone " + MsgNotValid,
+			})
+		})
+
+		g.It("skips the field when the condition does not hold", func() {
+			hints := filter.Validate(Customer{CustomerType: 1, Email: "me@example.com"})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("skips the field when the unless condition holds", func() {
+			filter := Filter{
+				{
+					Field:  "Phone",
+					Check:  NON_ZERO,
+					Unless: FieldEq("Status", "guest"),
+				},
+			}
+
+			g.Assert(len(filter.Validate(Customer{Status: "guest"}))).Equal(0)
+			g.Assert(filter.Validate(Customer{Status: "member"})).Equal([]string{
+				"phone " + MsgEmpty,
+			})
+		})
+
+		g.It("looks up the nested and the missing fields", func() {
+			filter := Filter{
+				{Field: "Phone", Check: NON_ZERO, When: FieldEq("Extra.callback", true)},
+				{Field: "Email", Check: NON_ZERO, When: FieldEq("Unknown", 1)},
+				{Field: "CompanyName", Check: NON_ZERO, Unless: FieldEmpty("Unknown")},
+			}
+
+			g.Assert(len(filter.Validate(Customer{}))).Equal(0)
+			g.Assert(filter.Validate(Customer{Extra: map[string]any{"callback": true}})).Equal([]string{
+				"phone " + MsgEmpty,
+			})
+		})
+
+		g.It("refers to the sibling fields of the sub-filter item", func() {
+			filter := Filter{{Field: "Contacts", Check: Each(filter)}}
+
+			g.Assert(filter.Validate(Customer{Contacts: []Customer{
+				{CustomerType: 2, Email: "me@example.com"},
+				{CustomerType: 1, Email: "me@example.com"},
+			}})).Equal([]string{
+				"contacts[0].companyName " + MsgEmpty,
+			})
+		})
+
+		g.It("applies to the map payloads", func() {
+			hints := filter.Validate(map[string]any{"CustomerType": 2, "Email": "me@example.com"})
+
+			g.Assert(hints).Equal([]string{"CompanyName " + MsgEmpty})
+		})
+
+		g.It("applies to the compiled filters", func() {
+			compiled, err := filter.Compile(Customer{})
+			data := Customer{CustomerType: 2}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(2)
+		})
+	})
+}
+
+func TestValidateRef(t *testing.T) {
+	type Booking struct {
+		Password        string     `json:"password"`
+		PasswordConfirm string     `json:"passwordConfirm"`
+		StartDate       time.Time  `json:"startDate"`
+		EndDate         time.Time  `json:"endDate"`
+		CheckIn         *time.Time `json:"checkIn"`
+		MinPrice        uint       `json:"minPrice"`
+		MaxPrice        float64    `json:"maxPrice"`
+		Price           int        `json:"price"`
+		Prices          []int      `json:"prices"`
+	}
+
+	g := Goblin(t)
+
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	g.Describe(`Rule with the field reference`, func() {
+		filter := Filter{
+			{Field: "PasswordConfirm", Check: Rule{"eq", Ref("Password")}},
+			{Field: "EndDate", Check: Rule{"date:min", Ref("StartDate")}},
+			{Field: "MaxPrice", Check: Rule{"min", Ref("MinPrice")}},
+			{Field: "Price", Check: Range{Ref("MinPrice"), Ref("MaxPrice")}},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Booking{
+				Password:        "secret",
+				PasswordConfirm: "secret",
+				StartDate:       start,
+				EndDate:         start.AddDate(0, 0, 1),
+				MinPrice:        10,
+				MaxPrice:        20.5,
+				Price:           15,
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("names the referenced fields in the hints", func() {
+			hints := filter.Validate(Booking{
+				Password:        "secret",
+				PasswordConfirm: "secreT",
+				StartDate:       start,
+				EndDate:         start.AddDate(0, 0, -1),
+				MinPrice:        10,
+				MaxPrice:        5,
+				Price:           30,
+			})
+
+			g.Assert(hints).Equal([]string{
+				"passwordConfirm " + fmt.Sprintf(MsgEq, "password"),
+				"endDate " + fmt.Sprintf(MsgMin, "startDate"),
+				"maxPrice " + fmt.Sprintf(MsgMin, "minPrice"),
+				"price " + fmt.Sprintf(MsgRange, "minPrice", "maxPrice"),
+			})
+		})
+
+		g.It("keeps the resolved prototype in the details", func() {
+			errs := filter[2:3].Errors(Booking{MinPrice: 10, MaxPrice: 5})
+
+			g.Assert(len(errs)).Equal(1, errs.Hints())
+			g.Assert(errs[0].Proto).Equal(uint(10))
+			g.Assert(errs[0].Args).Equal([]any{"minPrice"})
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{"maxPrice має бути не менше minPrice"})
+		})
+
+		g.It("mixes the references with the values", func() {
+			filter := Filter{{Field: "Price", Check: Range{1, Ref("MinPrice")}}}
+
+			g.Assert(filter.Validate(Booking{MinPrice: 10, Price: 11})).Equal([]string{
+				"price " + fmt.Sprintf(MsgRange, 1, "minPrice"),
+			})
+		})
+
+		g.It("applies with the modifiers", func() {
+			filter := Filter{{Field: "Prices", Check: Rule{"each:max", Ref("MaxPrice")}}}
+
+			g.Assert(filter.Validate(Booking{MaxPrice: 10, Prices: []int{5, 15}})).Equal([]string{
+				"prices item[1] " + fmt.Sprintf(MsgMax, "maxPrice"),
+			})
+		})
+
+		g.It("passes when the referenced field is nil", func() {
+			filter := Filter{{Field: "EndDate", Check: Rule{"date:min", Ref("CheckIn")}}}
+
+			g.Assert(len(filter.Validate(Booking{}))).Equal(0)
+			g.Assert(filter.Validate(Booking{CheckIn: &start})).Equal([]string{
+				"endDate " + fmt.Sprintf(MsgMin, "checkIn"),
+			})
+		})
+
+		g.It("failure when the referenced field is missing", func() {
+			filter := Filter{{Field: "Price", Check: Rule{"min", Ref("Unknown")}}}
+			errs := filter.Errors(Booking{})
+
+			g.Assert(errs.Hints()).Equal([]string{"price " + MsgInvalidRule})
+			g.Assert(errors.Is(errs, ErrInvalidRule)).IsTrue()
+		})
+
+		g.It("refers to the keys of the map payloads", func() {
+			hints := filter.Validate(map[string]any{
+				"Password":        "secret",
+				"PasswordConfirm": "secret!",
+			})
+
+			g.Assert(hints).Equal([]string{
+				"PasswordConfirm " + fmt.Sprintf(MsgEq, "Password"),
+			})
+		})
+
+		g.It("applies to the compiled filters", func() {
+			compiled, err := filter.Compile(Booking{})
+			data := Booking{Password: "a", MinPrice: 10}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(3)
+		})
+	})
+}
+
+func TestValidateFieldSet(t *testing.T) {
+	type Payment struct {
+		Email  string   `json:"email"`
+		Phone  *string  `json:"phone"`
+		CardId string   `json:"cardId"`
+		IBAN   string   `json:"iban"`
+		Lat    float64  `json:"lat"`
+		Lng    float64  `json:"lng"`
+		Tags   []string `json:"tags"`
+	}
+
+	g := Goblin(t)
+
+	phone := "+380501234567"
+
+	g.Describe(`Rule FieldSet`, func() {
+		filter := Filter{
+			{Check: AtLeastOne("Email", "Phone")},
+			{Check: ExactlyOne("CardId", "IBAN")},
+			{Field: "Email", Check: Rule{"max", 64}},
+			{Check: AllOrNone("Lat", "Lng")},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(len(filter.Validate(Payment{Email: "me@example.com", CardId: "1"}))).Equal(0)
+			g.Assert(len(filter.Validate(Payment{Phone: &phone, IBAN: "UA1", Lat: 50.4, Lng: 30.5}))).Equal(0)
+		})
+
+		g.It("lists the fields in the hints", func() {
+			hints := filter.Validate(Payment{CardId: "1", IBAN: "UA1", Lat: 50.4})
+
+			g.Assert(hints).Equal([]string{
+				fmt.Sprintf(MsgFieldsMin, 1, "email, phone"),
+				fmt.Sprintf(MsgFieldsEq, 1, "cardId, iban"),
+				fmt.Sprintf(MsgFieldsAllOrNone, "lat, lng"),
+			})
+		})
+
+		g.It("checks the number of the filled fields", func() {
+			filter := Filter{
+				{Check: FieldSet{Fields: []string{"Email", "Phone", "Tags"}, Max: 1}},
+				{Check: &FieldSet{Fields: []string{"CardId", "IBAN", "Lat"}, Min: 2, Max: 3}},
+			}
+
+			g.Assert(filter.Validate(Payment{Email: "me@example.com", Tags: []string{"a"}, Lat: 1})).Equal([]string{
+				fmt.Sprintf(MsgFieldsMax, 1, "email, phone, tags"),
+				fmt.Sprintf(MsgFieldsRange, 2, 3, "cardId, iban, lat"),
+			})
+			g.Assert(len(filter.Validate(Payment{Tags: []string{}, CardId: "1", IBAN: "UA1"}))).Equal(0)
+		})
+
+		g.It("describes the failed set", func() {
+			errs := filter[1:2].Errors(Payment{})
+
+			g.Assert(errs[0].Action).Equal("FieldSet")
+			g.Assert(errs[0].Proto).Equal([]string{"CardId", "IBAN"})
+			g.Assert(errs[0].Value).Equal(0)
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{"має бути заповнено рівно 1 з полів cardId, iban"})
+		})
+
+		g.It("renders the template of the set", func() {
+			filter := Filter{{Check: AtLeastOne("Email", "Phone"), Message: "Please enter an email or a phone"}}
+
+			g.Assert(filter.Validate(Payment{})).Equal([]string{"Please enter an email or a phone"})
+		})
+
+		g.It("applies to the map payloads", func() {
+			hints := filter[:1].Validate(map[string]any{"Email": "", "Phone": nil})
+
+			g.Assert(hints).Equal([]string{fmt.Sprintf(MsgFieldsMin, 1, "Email, Phone")})
+		})
+
+		g.It("failure when given a misconfigured set", func() {
+			items := []any{
+				FieldSet{},
+				FieldSet{Fields: []string{"Email"}, Min: 2, Max: 1},
+				FieldSet{Fields: []string{"Email"}, Min: -1},
+				FieldSet{Fields: []string{"Email"}, Min: 1, AllOrNone: true},
+				FieldSet{Fields: []string{"Email", "Phone"}},
+				AtLeastOne("Email", "Unknown"),
+				(*FieldSet)(nil),
+			}
+
+			for _, item := range items {
+				errs := Filter{{Check: item}}.Errors(Payment{Email: "me@example.com"})
+
+				g.Assert(errs.Hints()).Equal([]string{MsgInvalidRule}, item)
+			}
+		})
+
+		g.It("applies to the compiled filters", func() {
+			compiled, err := filter.Compile(Payment{})
+			data := Payment{CardId: "1", IBAN: "UA1", Lng: 1}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(3)
+		})
+	})
+}
+
+func TestValidateFieldNames(t *testing.T) {
+	type Address struct {
+		City string `json:"city,omitempty" form:"address_city"`
+	}
+
+	type Form struct {
+		Phone   string  `json:"phone,omitempty" form:"phone_number"`
+		Secret  string  `json:"-" form:"secret"`
+		Email   string  `json:",omitempty"`
+		Title   string  `json:"title"`
+		Address Address `json:"address" form:"address"`
+	}
+
+	g := Goblin(t)
+
+	filter := Filter{
+		{Field: "Phone", Check: NON_ZERO},
+		{Field: "Secret", Check: NON_ZERO},
+		{Field: "Email", Check: NON_ZERO},
+		{Field: "Title", Check: NON_ZERO},
+		{Field: "Address.City", Check: NON_ZERO},
+	}
+
+	g.Describe(`Field names`, func() {
+		g.It("parses the json tags", func() {
+			g.Assert(filter.Validate(Form{})).Equal([]string{
+				"phone " + MsgEmpty,
+				"Secret " + MsgEmpty,
+				"Email " + MsgEmpty,
+				"title " + MsgEmpty,
+				"address.city " + MsgEmpty,
+			})
+		})
+
+		g.It("names the fields by the Go names", func() {
+			v := New(FieldNames(GoName))
+
+			g.Assert(v.Validate(filter, Form{})).Equal([]string{
+				"Phone " + MsgEmpty,
+				"Secret " + MsgEmpty,
+				"Email " + MsgEmpty,
+				"Title " + MsgEmpty,
+				"Address.City " + MsgEmpty,
+			})
+		})
+
+		g.It("names the fields by the custom tag", func() {
+			v := New(FieldNames(NameByTag("form")))
+
+			g.Assert(v.Validate(filter, Form{})).Equal([]string{
+				"phone_number " + MsgEmpty,
+				"secret " + MsgEmpty,
+				"Email " + MsgEmpty,
+				"Title " + MsgEmpty,
+				"address.address_city " + MsgEmpty,
+			})
+		})
+
+		g.It("names the fields by the function", func() {
+			v := New(FieldNames(func(field reflect.StructField) string {
+				if field.Name == "Title" {
+					return ""
+				}
+
+				return strings.ToUpper(field.Name)
+			}))
+
+			g.Assert(v.Validate(filter[3:], Form{})).Equal([]string{
+				"title " + MsgEmpty,
+				"ADDRESS.CITY " + MsgEmpty,
+			})
+		})
+
+		g.It("names the fields of the compiled filters", func() {
+			v := New(FieldNames(NameByTag("form")))
+			compiled, err := v.Compile(filter, Form{})
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(Form{})).Equal(v.Validate(filter, Form{}))
+		})
+
+		g.It("names the fields of the references and the field sets", func() {
+			v := New(FieldNames(NameByTag("form")))
+			filter := Filter{
+				{Field: "Title", Check: Rule{"eq", Ref("Phone")}},
+				{Check: AtLeastOne("Phone", "Secret")},
+			}
+
+			g.Assert(v.Validate(filter, Form{Title: "x"})).Equal([]string{
+				"Title " + fmt.Sprintf(MsgEq, "phone_number"),
+				fmt.Sprintf(MsgFieldsMin, 1, "phone_number, secret"),
+			})
+		})
+	})
+}
+
+func TestValidateIn(t *testing.T) {
+	type Status string
+
+	type Article struct {
+		Status Status             `json:"status"`
+		Sex    uint8              `json:"sex"`
+		Rate   float32            `json:"rate"`
+		Score  *int               `json:"score"`
+		Tags   []string           `json:"tags"`
+		Limits map[string]int     `json:"limits"`
+		Meta   map[string]Article `json:"meta"`
+	}
+
+	g := Goblin(t)
+
+	score := 5
+
+	g.Describe(`Rule "in"`, func() {
+		filter := Filter{
+			{Field: "Status", Check: Rule{"in", []any{"draft", "published", "archived"}}},
+			{Field: "Sex", Check: Rule{"in", []int{1, 2}}},
+			{Field: "Rate", Check: Rule{"in", []any{0, 0.5, 1}}},
+			{Field: "Score", Check: Rule{"in", []uint{1, 5}}, Optional: true},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Article{Status: "draft", Sex: 2, Rate: 0.5, Score: &score})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("lists the allowed values in the hints", func() {
+			score := 3
+			hints := filter.Validate(Article{Status: "deleted", Sex: 3, Rate: 0.25, Score: &score})
+
+			g.Assert(hints).Equal([]string{
+				"status " + fmt.Sprintf(MsgIn, "draft, published, archived"),
+				"sex " + fmt.Sprintf(MsgIn, "1, 2"),
+				"rate " + fmt.Sprintf(MsgIn, "0, 0.5, 1"),
+				"score " + fmt.Sprintf(MsgIn, "1, 5"),
+			})
+		})
+
+		g.It("compares the numbers across the types", func() {
+			filter := Filter{{Field: "Sex", Check: Rule{"in", []any{int64(1), uint(2), 3.0}}}}
+
+			g.Assert(len(filter.Validate(Article{Sex: 3}))).Equal(0)
+			g.Assert(len(filter.Validate(Article{Sex: 4}))).Equal(1)
+
+			// a string is not a number
+			filter = Filter{{Field: "Sex", Check: Rule{"in", []any{"1"}}}}
+			g.Assert(len(filter.Validate(Article{Sex: 1}))).Equal(1)
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			items := []any{nil, 1, []any{}, "draft"}
+
+			for _, proto := range items {
+				hints := Filter{{Field: "Status", Check: Rule{"in", proto}}}.Validate(Article{})
+
+				g.Assert(hints).Equal([]string{"status " + MsgInvalidRule}, proto)
+			}
+		})
+
+		g.It("failure when given an unsupported value", func() {
+			hints := Filter{
+				{Field: "Tags", Check: Rule{"in", []any{"a"}}},
+				{Field: "Score", Check: Rule{"in", []any{1}}},
+			}.Validate(Article{Tags: []string{"a"}})
+
+			g.Assert(hints).Equal([]string{
+				"tags " + MsgUnsupportType,
+				"score " + MsgInvalidValue,
+			})
+		})
+
+		g.It("localizes the hints", func() {
+			errs := filter[:1].Errors(Article{})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{
+				"status має бути одним із draft, published, archived",
+			})
+		})
+	})
+
+	g.Describe(`Rule "notIn"`, func() {
+		filter := Filter{
+			{Field: "Status", Check: Rule{"notIn", []string{"deleted", "banned"}}},
+			{Field: "Sex", Check: Rule{"notIn", []any{0}}},
+		}
+
+		g.It("success when given valid values", func() {
+			g.Assert(len(filter.Validate(Article{Status: "draft", Sex: 1}))).Equal(0)
+		})
+
+		g.It("lists the forbidden values in the hints", func() {
+			g.Assert(filter.Validate(Article{Status: "banned"})).Equal([]string{
+				"status " + fmt.Sprintf(MsgNotIn, "deleted, banned"),
+				"sex " + fmt.Sprintf(MsgNotIn, "0"),
+			})
+		})
+	})
+
+	g.Describe(`Rule "each:in"`, func() {
+		filter := Filter{
+			{Field: "Tags", Check: Rule{"each:in", []any{"go", "rust"}}},
+			{Field: "Limits", Check: Rule{"each:notIn", []any{0}}},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Article{Tags: []string{"go", "rust"}, Limits: map[string]int{"a": 1}})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when at least 1 value is not allowed", func() {
+			hints := filter.Validate(Article{Tags: []string{"go", "java"}, Limits: map[string]int{"a": 0}})
+
+			g.Assert(hints).Equal([]string{
+				"tags item[1] " + fmt.Sprintf(MsgIn, "go, rust"),
+				"limits item[a] " + fmt.Sprintf(MsgNotIn, "0"),
+			})
+		})
+
+		g.It("compiles the rules", func() {
+			filter := append(filter, FilterItem{Field: "Status", Check: Rule{"in", []any{"draft"}}})
+			compiled, err := filter.Compile(Article{})
+			data := Article{Tags: []string{"java"}, Status: "x"}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+
+			_, err = Filter{{Field: "Meta", Check: Rule{"each:in", []any{"a"}}}}.Compile(Article{})
+			g.Assert(errors.Is(err, ErrInvalidRule)).IsTrue(err)
+		})
+	})
+}
+
+func TestValidateContains(t *testing.T) {
+	type Page struct {
+		Url    string            `json:"url"`
+		Title  *string           `json:"title"`
+		Slug   string            `json:"slug"`
+		Views  int               `json:"views"`
+		Images []string          `json:"images"`
+		Links  map[string]string `json:"links"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Rules "contains", "notContains", "prefix", "suffix"`, func() {
+		filter := Filter{
+			{Field: "Url", Check: Group{Rule{"prefix", "https://"}, Rule{"suffix", ".html"}}},
+			{Field: "Title", Check: Rule{"contains", "Go"}, Optional: true},
+			{Field: "Slug", Check: Rule{"notContains", "--"}},
+		}
+
+		g.It("success when given valid values", func() {
+			title := "Learn Go"
+			hints := filter.Validate(Page{Url: "https://example.com/go.html", Title: &title, Slug: "learn-go"})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid values", func() {
+			title := "Learn go"
+			hints := filter.Validate(Page{Url: "http://example.com/go.htm", Title: &title, Slug: "learn--go"})
+
+			g.Assert(hints).Equal([]string{
+				"url " + fmt.Sprintf(MsgPrefix, "https://"),
+				"title " + fmt.Sprintf(MsgContains, "Go"),
+				"slug " + fmt.Sprintf(MsgNotContains, "--"),
+			})
+
+			hints = filter[:1].Validate(Page{Url: "https://example.com/go.htm"})
+			g.Assert(hints).Equal([]string{"url " + fmt.Sprintf(MsgSuffix, ".html")})
+		})
+
+		g.It("ignores the case with the i variants", func() {
+			filter := Filter{
+				{Field: "Url", Check: Group{Rule{"iprefix", "HTTPS://"}, Rule{"isuffix", ".HTML"}}},
+				{Field: "Slug", Check: Group{Rule{"icontains", "GO"}, Rule{"inotContains", "Admin"}}},
+			}
+
+			g.Assert(len(filter.Validate(Page{Url: "https://example.com/Go.Html", Slug: "learn-go"}))).Equal(0)
+			g.Assert(filter.Validate(Page{Url: "Https://example.com/go.HTML", Slug: "ADMIN-go"})).Equal([]string{
+				"slug " + fmt.Sprintf(MsgNotContains, "Admin"),
+			})
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			for _, proto := range []any{nil, "", 1} {
+				hints := Filter{{Field: "Slug", Check: Rule{"contains", proto}}}.Validate(Page{})
+
+				g.Assert(hints).Equal([]string{"slug " + MsgInvalidRule}, proto)
+			}
+		})
+
+		g.It("failure when given an unsupported value", func() {
+			hints := Filter{
+				{Field: "Views", Check: Rule{"contains", "1"}},
+				{Field: "Title", Check: Rule{"contains", "1"}},
+			}.Validate(Page{Views: 1})
+
+			g.Assert(hints).Equal([]string{
+				"views " + MsgUnsupportType,
+				"title " + MsgInvalidValue,
+			})
+		})
+
+		g.It("localizes the hints", func() {
+			errs := filter[:1].Errors(Page{})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{`url має починатися з "https://"`})
+		})
+	})
+
+	g.Describe(`Rules "each:contains", "each:prefix"`, func() {
+		filter := Filter{
+			{Field: "Images", Check: Rule{"each:prefix", "https://"}},
+			{Field: "Links", Check: Rule{"each:inotContains", "javascript:"}},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Page{
+				Images: []string{"https://img.it/1.png"},
+				Links:  map[string]string{"home": "/"},
+			})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when at least 1 value does not match", func() {
+			hints := filter.Validate(Page{
+				Images: []string{"https://img.it/1.png", "http://img.it/2.png"},
+				Links:  map[string]string{"home": "JavaScript:alert(1)"},
+			})
+
+			g.Assert(hints).Equal([]string{
+				"images item[1] " + fmt.Sprintf(MsgPrefix, "https://"),
+				"links item[home] " + fmt.Sprintf(MsgNotContains, "javascript:"),
+			})
+		})
+
+		g.It("compiles the rules", func() {
+			compiled, err := filter.Compile(Page{})
+			data := Page{Images: []string{"ftp://img.it/1.png"}}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+		})
+	})
+}
+
+func TestValidateCharClasses(t *testing.T) {
+	type Profile struct {
+		Name  string            `json:"name"`
+		Login *string           `json:"login"`
+		Pin   string            `json:"pin"`
+		Age   int               `json:"age"`
+		Tags  []string          `json:"tags"`
+		Codes map[string]string `json:"codes"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Character class rules`, func() {
+		items := []struct {
+			action  string
+			proto   any
+			valid   []string
+			invalid []string
+			message string
+		}{
+			{"alpha", nil, []string{"", "Olena", "Олена", "Ñandú"}, []string{"Olena1", "Olena K"}, MsgAlpha},
+			{"alpha", ASCII, []string{"Olena"}, []string{"Олена", "Ñandú"}, MsgAlphaASCII},
+			{"alnum", nil, []string{"Олена2024"}, []string{"Olena_1"}, MsgAlnum},
+			{"alnum", ASCII, []string{"Olena2024"}, []string{"Олена2024"}, MsgAlnumASCII},
+			{"numeric", nil, []string{"0123", "٠١٢"}, []string{"-1", "1.5", "½"}, MsgNumeric},
+			{"numeric", ASCII, []string{"0123"}, []string{"٠١٢"}, MsgNumeric},
+			{"ascii", nil, []string{"Hello, world!\n"}, []string{"Привіт"}, MsgASCII},
+			{"printable", nil, []string{"Привіт, world!"}, []string{"tab\t", "bell\a"}, MsgPrintable},
+			{"printable", ASCII, []string{"Hello, world!"}, []string{"Привіт"}, MsgPrintableASCII},
+			{"lower", nil, []string{"hello-1", "привіт"}, []string{"Hello", "ǅ"}, MsgLower},
+			{"upper", nil, []string{"HELLO-1", "ПРИВІТ"}, []string{"HELLo"}, MsgUpper},
+			{"noSpace", nil, []string{"hello-world"}, []string{"hello world", "hello world"}, MsgNoSpace},
+		}
+
+		for _, item := range items {
+			item := item
+			filter := Filter{{Field: "Name", Check: Rule{item.action, item.proto}}}
+
+			g.It(fmt.Sprintf("checks the %q rule with the %v prototype", item.action, item.proto), func() {
+				for _, value := range item.valid {
+					hints := filter.Validate(Profile{Name: value})
+					g.Assert(len(hints)).Equal(0, value, hints)
+				}
+
+				for _, value := range item.invalid {
+					g.Assert(filter.Validate(Profile{Name: value})).Equal([]string{"name " + item.message}, value)
+				}
+			})
+		}
+
+		g.It("checks the pointers", func() {
+			login := "Admin"
+			filter := Filter{{Field: "Login", Check: Rule{"lower", nil}}}
+
+			g.Assert(filter.Validate(Profile{Login: &login})).Equal([]string{"login " + MsgLower})
+			g.Assert(filter.Validate(Profile{})).Equal([]string{"login " + MsgInvalidValue})
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			for _, rule := range []Rule{{"alpha", "latin"}, {"lower", ASCII}, {"ascii", ASCII}, {"numeric", 1}} {
+				hints := Filter{{Field: "Name", Check: rule}}.Validate(Profile{})
+
+				g.Assert(hints).Equal([]string{"name " + MsgInvalidRule}, rule)
+			}
+		})
+
+		g.It("failure when given an unsupported value", func() {
+			g.Assert(Filter{{Field: "Age", Check: Rule{"numeric", nil}}}.Validate(Profile{})).Equal([]string{
+				"age " + MsgUnsupportType,
+			})
+		})
+
+		g.It("localizes the hints", func() {
+			errs := Filter{{Field: "Pin", Check: Rule{"numeric", ASCII}}}.Errors(Profile{Pin: "12a"})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{"pin має містити лише цифри"})
+		})
+	})
+
+	g.Describe(`Character class rules with the "each" modifier`, func() {
+		filter := Filter{
+			{Field: "Tags", Check: Rule{"each:alnum", ASCII}},
+			{Field: "Codes", Check: Rule{"each:upper", nil}},
+		}
+
+		g.It("success when given valid values", func() {
+			hints := filter.Validate(Profile{Tags: []string{"go", "rust"}, Codes: map[string]string{"ua": "UA"}})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when at least 1 value does not match", func() {
+			hints := filter.Validate(Profile{Tags: []string{"go", "c++"}, Codes: map[string]string{"ua": "Ua"}})
+
+			g.Assert(hints).Equal([]string{
+				"tags item[1] " + MsgAlnumASCII,
+				"codes item[ua] " + MsgUpper,
+			})
+		})
+
+		g.It("applies after the other modifiers", func() {
+			v := New()
+			v.RegisterRule("sku", func(proto, value reflect.Value) string {
+				if !regexp.MustCompile(`^[A-Z]{3}-\d+$`).MatchString(value.String()) {
+					return "must be a SKU"
+				}
+
+				return ""
+			})
+
+			filter := Filter{
+				{Field: "Tags", Check: Group{Rule{"each:trim:utf8", nil}, Rule{"each:trim:alpha", nil}}},
+				{Field: "Codes", Check: Rule{"each:trim:sku", nil}},
+			}
+
+			g.Assert(len(v.Validate(filter, Profile{Tags: []string{" go "}, Codes: map[string]string{"a": " ABC-1 "}}))).Equal(0)
+			g.Assert(v.Validate(filter, Profile{Tags: []string{" go ", " c++ "}, Codes: map[string]string{"a": " abc "}})).Equal([]string{
+				"tags item[1] " + MsgAlpha,
+				"codes item[a] must be a SKU",
+			})
+			g.Assert(v.Validate(filter, Profile{Tags: []string{"\xff"}})).Equal([]string{"tags item[0] " + MsgUTF8})
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			for _, rule := range []Rule{{"each:min", nil}, {"each:trim:max", nil}, {"each:match", nil}} {
+				hints := Filter{{Field: "Tags", Check: rule}}.Validate(Profile{Tags: []string{"go"}})
+
+				g.Assert(hints).Equal([]string{"tags " + MsgInvalidRule}, rule)
+			}
+		})
+
+		g.It("compiles the rules", func() {
+			filter := append(filter, FilterItem{Field: "Pin", Check: Rule{"numeric", ASCII}})
+			compiled, err := filter.Compile(Profile{})
+			data := Profile{Tags: []string{"c++"}, Pin: "12a"}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(2)
+		})
+	})
+}
+
+func TestValidateBytes(t *testing.T) {
+	type Account struct {
+		Name  string   `json:"name"`
+		Login string   `json:"login"`
+		Age   int      `json:"age"`
+		Tags  []string `json:"tags"`
+	}
+
+	g := Goblin(t)
+
+	g.Describe(`Length rules with the "len" and "bytes" modifiers`, func() {
+		items := []struct {
+			rule    Rule
+			valid   []string
+			invalid []string
+			message string
+		}{
+			{Rule{"bytes:min", 4}, []string{"Ол", "Olena"}, []string{"О", "Ola"}, fmt.Sprintf(MsgMinBytes, 4)},
+			{Rule{"bytes:max", 6}, []string{"Оля", "Olena"}, []string{"Олена", "Olena K"}, fmt.Sprintf(MsgMaxBytes, 6)},
+			{Rule{"bytes:eq", 6}, []string{"Оля", "Ola-K1"}, []string{"Ola", "Олена"}, fmt.Sprintf(MsgEqBytes, 6)},
+			{Rule{"bytes:range", Range{2, 6}}, []string{"О", "Оля"}, []string{"O", "Олена"}, fmt.Sprintf(MsgRangeBytes, 2, 6)},
+			{Rule{"len:max", 6}, []string{"Оля", "Olena"}, []string{"Олена", "Olena K"}, fmt.Sprintf(MsgMaxBytes, 6)},
+			{Rule{"len:range", Range{2, 6}}, []string{"О", "Оля"}, []string{"O", "Олена"}, fmt.Sprintf(MsgRangeBytes, 2, 6)},
+		}
+
+		for _, item := range items {
+			item := item
+			filter := Filter{{Field: "Name", Check: item.rule}}
+
+			g.It(fmt.Sprintf("checks the %q rule", item.rule[0]), func() {
+				for _, value := range item.valid {
+					hints := filter.Validate(Account{Name: value})
+					g.Assert(len(hints)).Equal(0, value, hints)
+				}
+
+				for _, value := range item.invalid {
+					g.Assert(filter.Validate(Account{Name: value})).Equal([]string{"name " + item.message}, value)
+				}
+			})
+		}
+
+		g.It("measures the characters without the modifier", func() {
+			g.Assert(len(Filter{{Field: "Name", Check: Rule{"max", 6}}}.Validate(Account{Name: "Олена"}))).Equal(0)
+		})
+
+		g.It("counts the items of the collections", func() {
+			for _, action := range []string{"len:max", "bytes:max"} {
+				hints := Filter{{Field: "Tags", Check: Rule{action, 1}}}.Validate(Account{Tags: []string{"go", "rust"}})
+
+				g.Assert(hints).Equal([]string{"tags " + fmt.Sprintf(MsgMax, 1)}, action)
+			}
+		})
+
+		g.It("failure when given an unsupported value", func() {
+			g.Assert(Filter{{Field: "Age", Check: Rule{"bytes:max", 8}}}.Validate(Account{})).Equal([]string{
+				"age " + MsgUnsupportType,
+			})
+		})
+
+		g.It("failure when given an invalid value", func() {
+			hints := Filter{{Field: "nick", Check: Rule{"bytes:max", 8}}}.Validate(map[string]any{"nick": nil})
+
+			g.Assert(hints).Equal([]string{"nick " + MsgInvalidValue})
+		})
+
+		g.It("localizes the hints", func() {
+			errs := Filter{{Field: "Name", Check: Rule{"bytes:max", 4}}}.Errors(Account{Name: "Олена"})
+
+			g.Assert(errs.Localize("uk").Hints()).Equal([]string{"name має містити не більше 4 байтів"})
+		})
+	})
+
+	g.Describe(`The "utf8" rule`, func() {
+		filter := Filter{
+			{Field: "Name", Check: Rule{"utf8", nil}},
+			{Field: "Tags", Check: Rule{"each:utf8", nil}},
+		}
+
+		g.It("success when given valid text", func() {
+			hints := filter.Validate(Account{Name: "Олена", Tags: []string{"go", ""}})
+
+			g.Assert(len(hints)).Equal(0, hints)
+		})
+
+		g.It("failure when given invalid bytes", func() {
+			hints := filter.Validate(Account{Name: "Ол\xffена", Tags: []string{"go", "\xc3"}})
+
+			g.Assert(hints).Equal([]string{
+				"name " + MsgUTF8,
+				"tags item[1] " + MsgUTF8,
+			})
+		})
+
+		g.It("length rules do not reject the invalid bytes", func() {
+			data := Account{Name: "\xff\xfe"}
+
+			g.Assert(len(Filter{{Field: "Name", Check: Rule{"range", Range{1, 8}}}}.Validate(data))).Equal(0)
+			g.Assert(Filter{{Field: "Name", Check: Group{Rule{"utf8", nil}, Rule{"range", Range{1, 8}}}}}.Validate(data)).Equal([]string{
+				"name " + MsgUTF8,
+			})
+		})
+
+		g.It("failure when given a misconfigured rule", func() {
+			g.Assert(Filter{{Field: "Name", Check: Rule{"utf8", true}}}.Validate(Account{})).Equal([]string{
+				"name " + MsgInvalidRule,
+			})
+		})
+
+		g.It("failure when given an unsupported value", func() {
+			g.Assert(Filter{{Field: "Age", Check: Rule{"utf8", nil}}}.Validate(Account{})).Equal([]string{
+				"age " + MsgUnsupportType,
+			})
+		})
+
+		g.It("compiles the rules", func() {
+			filter := append(filter, FilterItem{Field: "Login", Check: Rule{"bytes:range", Range{1, 8}}})
+			compiled, err := filter.Compile(Account{})
+			data := Account{Name: "\xff", Tags: []string{"\xc3"}}
+
+			g.Assert(err).IsNil()
+			g.Assert(compiled.Validate(data)).Equal(filter.Validate(data))
+			g.Assert(len(compiled.Validate(data))).Equal(3)
+		})
+	})
+}
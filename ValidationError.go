@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrNotValid       = errors.New(MsgNotValid)
+	ErrEmpty          = errors.New(MsgEmpty)
+	ErrUnsupportType  = errors.New(MsgUnsupportType)
+	ErrInvalidValue   = errors.New(MsgInvalidValue)
+	ErrInvalidRule    = errors.New(MsgInvalidRule)
+	ErrInvalidBodyVal = errors.New(MsgInvalidBodyVal)
+	ErrTruncated      = errors.New(MsgTruncated)
+)
+
+// Describes a single failed check of the filter item
+type ValidationError struct {
+	// Hint name of the field, e.g. the json tag
+	Field string
+
+	// Path to the field in the Go structure, e.g. "Address.City"
+	Path string
+
+	// Rule action that failed, e.g. "min", "each:match", NON_ZERO
+	Action string
+
+	// Prototype value of the rule
+	Proto any
+
+	// Value that did not pass the check
+	Value any
+
+	// Hint message, e.g. "must be at least 1"
+	Message string
+
+	// Stable ID of the message, e.g. "min_str_len", which selects
+	// the message of the Catalog. Empty for the messages of the custom rules
+	Code string
+
+	// Arguments of the message, e.g. the threshold of the rule
+	Args []any
+
+	// One of the Err* sentinel errors
+	kind error
+
+	// The message is rendered from the template of the FilterItem,
+	// so it makes the whole hint without the field name
+	template bool
+
+	// The errors of the failed alternatives of the AnyOf and the OneOf,
+	// which the message joins
+	causes ValidationErrors
+}
+
+type ValidationErrors []*ValidationError
+
+func newError(format string, args ...any) *ValidationError {
+	kind := ErrNotValid
+	code := messageCodes[format]
+
+	switch format {
+	case MsgEmpty:
+		kind = ErrEmpty
+	case MsgUnsupportType:
+		kind = ErrUnsupportType
+	case MsgInvalidValue:
+		kind = ErrInvalidValue
+	case MsgInvalidRule:
+		kind = ErrInvalidRule
+	case MsgInvalidBodyVal:
+		kind = ErrInvalidBodyVal
+	case MsgTruncated:
+		kind = ErrTruncated
+	}
+
+	if len(args) > 0 {
+		format = fmt.Sprintf(format, args...)
+	}
+
+	return &ValidationError{Message: format, Code: code, Args: args, kind: kind}
+}
+
+// Converts the error returned by the modifier. The error of the rule wrapped
+// by the modifier keeps its details and category, while taking the wrapping
+// message, e.g. "key[color] must contain at least 3 characters"
+func asValidationError(err error) *ValidationError {
+	var target *ValidationError
+
+	switch {
+	case err == nil:
+		return nil
+
+	case !errors.As(err, &target):
+		return newError(err.Error())
+
+	case error(target) == err:
+		return target
+	}
+
+	wrapped := *target
+	wrapped.Message = err.Error()
+
+	return &wrapped
+}
+
+// Fills in the details of the failed rule. The details that are already
+// known (e.g. an item of the collection within the "each" modifier) remain
+func (e *ValidationError) describe(action string, proto, value reflect.Value) {
+	e.Action = action
+
+	if e.Proto == nil && proto.IsValid() && proto.CanInterface() {
+		e.Proto = proto.Interface()
+	}
+
+	if e.Value == nil && value.IsValid() && value.CanInterface() {
+		e.Value = value.Interface()
+	}
+}
+
+// Renders the error in the form of a hint, as it returned by Filter.Validate
+func (e *ValidationError) String() string {
+	if e.Field == "" || e.template {
+		return e.Message
+	}
+
+	return e.Field + " " + e.Message
+}
+
+func (e *ValidationError) Error() string {
+	return e.String()
+}
+
+// Returns the sentinel error of the failure category, so that
+// errors.Is(err, ErrInvalidRule) tells a misconfigured rule apart
+// from the invalid data
+func (e *ValidationError) Unwrap() error {
+	return e.kind
+}
+
+func (errs ValidationErrors) Error() string {
+	return strings.Join(errs.Hints(), "; ")
+}
+
+// Returns each of the errors, so that errors.Is and errors.As
+// inspect every failed filter item
+func (errs ValidationErrors) Unwrap() []error {
+	list := make([]error, len(errs))
+
+	for n, err := range errs {
+		list[n] = err
+	}
+
+	return list
+}
+
+// Renders the errors in the form of hints, as they returned by Filter.Validate
+func (errs ValidationErrors) Hints() []string {
+	hints := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		hints = append(hints, err.String())
+	}
+
+	return hints
+}
@@ -8,6 +8,7 @@ package validator
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -21,63 +22,126 @@ func TestCoverageFilter(t *testing.T) {
 
 	g.Describe(`For Coverage: filter`, func() {
 		g.It("failure filterMin when given invalid value", func() {
-			hint := filterMin(
+			err := filterMin(
 				reflect.ValueOf(NON_ZERO),
 				reflect.ValueOf(nil),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidValue, hint)
+			g.Assert(err.Message).Equal(MsgInvalidValue, err)
 		})
 
 		g.It("failure filterMax when given invalid value", func() {
-			hint := filterMax(
+			err := filterMax(
 				reflect.ValueOf(NON_ZERO),
 				reflect.ValueOf(nil),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidValue, hint)
+			g.Assert(err.Message).Equal(MsgInvalidValue, err)
 		})
 
 		g.It("failure filterEq when given invalid value", func() {
-			hint := filterEq(
+			err := filterEq(
 				reflect.ValueOf(NON_ZERO),
 				reflect.ValueOf(nil),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidValue, hint)
+			g.Assert(err.Message).Equal(MsgInvalidValue, err)
 		})
 
 		g.It("failure filterRange when given invalid value", func() {
-			hint := filterRange(
+			err := filterRange(
 				reflect.ValueOf(Range{1, 2}),
 				reflect.ValueOf(nil),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidValue, hint)
+			g.Assert(err.Message).Equal(MsgInvalidValue, err)
 		})
 
 		g.It("failure filterDate when given invalid rule", func() {
 			now := time.Now()
 
-			hint := filterDate(
+			err := filterDate(
 				"invalid-rule",
 				reflect.ValueOf(now),
 				reflect.ValueOf(now),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidRule, hint)
+			g.Assert(err.Message).Equal(MsgInvalidRule, err)
 		})
 
 		g.It("failure filterTime when given invalid rule", func() {
 			now := time.Now()
 
-			hint := filterTime(
+			err := filterTime(
 				"invalid-rule",
 				reflect.ValueOf(now),
 				reflect.ValueOf(now),
 			)
 
-			g.Assert(hint).Equal(MsgInvalidRule, hint)
+			g.Assert(err.Message).Equal(MsgInvalidRule, err)
+		})
+	})
+}
+
+// go test -v -run TestCoveragePatternCache .
+
+func TestCoveragePatternCache(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe(`For Coverage: pattern cache`, func() {
+		g.It("compiles the pattern once", func() {
+			cache := &patternCache{items: map[string]patternEntry{}}
+
+			re1, err1 := cache.compile(`^\d+$`)
+			re2, err2 := cache.compile(`^\d+$`)
+
+			g.Assert(err1 == nil && err2 == nil).IsTrue()
+			g.Assert(re1 == re2).IsTrue()
+		})
+
+		g.It("keeps the error of the invalid pattern", func() {
+			cache := &patternCache{items: map[string]patternEntry{}}
+
+			_, err := cache.compile(`:)`)
+			_, found := cache.items[`:)`]
+
+			g.Assert(err == nil).IsFalse()
+			g.Assert(found).IsTrue()
+		})
+
+		g.It("evicts the oldest pattern when full", func() {
+			cache := &patternCache{items: map[string]patternEntry{}}
+
+			for n := 0; n <= patternsCacheSize; n++ {
+				cache.compile(strconv.Itoa(n))
+			}
+
+			_, found := cache.items["0"]
+
+			g.Assert(found).IsFalse()
+			g.Assert(len(cache.items)).Equal(patternsCacheSize)
+			g.Assert(len(cache.order)).Equal(patternsCacheSize)
+		})
+
+		g.It("is safe for concurrent use", func() {
+			cache := &patternCache{items: map[string]patternEntry{}}
+			done := make(chan bool)
+
+			for n := 0; n < 8; n++ {
+				go func(n int) {
+					for i := 0; i < 100; i++ {
+						cache.compile(strconv.Itoa((n + i) % 16))
+					}
+
+					done <- true
+				}(n)
+			}
+
+			for n := 0; n < 8; n++ {
+				<-done
+			}
+
+			g.Assert(len(cache.items)).Equal(16)
 		})
 	})
 }
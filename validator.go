@@ -2,17 +2,24 @@ package validator
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 	"unicode/utf8"
 )
 
 const (
 	NON_ZERO = "NonZero"
 
+	// Restricts the character class rule to ASCII, e.g. Rule{"alpha", ASCII}
+	ASCII = "ascii"
+
 	MsgMinStrLen      = "must contain at least %v characters"
 	MsgMaxStrLen      = "must contain up to %v characters"
 	MsgEqStrLen       = "must contain exactly %v characters"
@@ -21,16 +28,51 @@ const (
 	MsgMaxSetLen      = "must contain up to %v items"
 	MsgEqSetLen       = "must contain exactly %v items"
 	MsgRangeSetLen    = "must contain %v..%v items"
+	MsgMinBytes       = "must contain at least %v bytes"
+	MsgMaxBytes       = "must contain up to %v bytes"
+	MsgEqBytes        = "must contain exactly %v bytes"
+	MsgRangeBytes     = "must contain %v..%v bytes"
 	MsgMin            = "must be at least %v"
 	MsgMax            = "must be up to %v"
 	MsgEq             = "must be exactly %v"
 	MsgRange          = "must be in the range %v..%v"
+	MsgIn             = "must be one of %v"
+	MsgNotIn          = "must not be one of %v"
+	MsgContains       = "must contain %q"
+	MsgNotContains    = "must not contain %q"
+	MsgPrefix         = "must start with %q"
+	MsgSuffix         = "must end with %q"
+	MsgNotPrefix      = "must not start with %q"
+	MsgNotSuffix      = "must not end with %q"
+	MsgNotMatch       = "must not match %v"
+	MsgNotRule        = "must not pass the %v rule"
+	MsgAlpha          = "must contain only letters"
+	MsgAlphaASCII     = "must contain only Latin letters"
+	MsgAlnum          = "must contain only letters and digits"
+	MsgAlnumASCII     = "must contain only Latin letters and digits"
+	MsgNumeric        = "must contain only digits"
+	MsgASCII          = "must contain only ASCII characters"
+	MsgPrintable      = "must contain only printable characters"
+	MsgPrintableASCII = "must contain only printable ASCII characters"
+	MsgLower          = "must not contain uppercase letters"
+	MsgUpper          = "must not contain lowercase letters"
+	MsgNoSpace        = "must not contain white space"
+	MsgUTF8           = "must be valid UTF-8 text"
 	MsgNotValid       = "is not valid"
 	MsgEmpty          = "is empty"
 	MsgUnsupportType  = "has unsupported type to validate"
 	MsgInvalidValue   = "has invalid value"
 	MsgInvalidRule    = "has invalid rule"
 	MsgInvalidBodyVal = "invalid body value"
+	MsgTruncated      = "validation stopped, further errors are not reported"
+	MsgAnyOf          = "%v or %v"
+	MsgOneOf          = "must satisfy exactly one of the rules"
+
+	MsgFieldsMin       = "at least %v of %v must be filled"
+	MsgFieldsMax       = "up to %v of %v may be filled"
+	MsgFieldsEq        = "exactly %v of %v must be filled"
+	MsgFieldsRange     = "%v..%v of %v must be filled"
+	MsgFieldsAllOrNone = "either all or none of %v must be filled"
 )
 
 var (
@@ -38,54 +80,151 @@ var (
 )
 
 type Group []any
+
+// Checks each of the rules like the Group, but reports every failed rule
+// rather than the first one, e.g. AllOf{Rule{"min", 8}, Rule{"match", `\d`}}
+type AllOf []any
+
+// Passes if at least one of the rules passes, e.g. a phone or an email
+//
+//	AnyOf{Rule{"match", `^\d{10}$`}, Rule{"match", `@`}}
+//
+// Otherwise, reports the hints of the failed rules joined with "or"
+type AnyOf []any
+
+// Passes if exactly one of the rules passes. Reports the hints of the failed
+// rules like the AnyOf if none passes, and MsgOneOf if several pass
+type OneOf []any
+
+// Passes if the rule does not pass, e.g. Not{Rule{"match", "^admin"}}
+type Not [1]any
+
 type Range [2]any
 type Rule [2]any
 
+// Checks each element of the array, slice, or map of structures
+// (or maps with string keys) with its own filter, e.g. Each(lineItemFilter)
+type Each Filter
+
 type FilterItem struct {
 	Field    string
 	Check    any
 	Optional bool
+
+	// Template of the hint used in place of the default one, e.g.
+	// "Please enter {field} in +38XXXXXXXXXX format". The placeholders are
+	// {field}, {value}, {proto}, {min} and {max}
+	Message string
+
+	// Templates of the hints by the actions of the rules, e.g. "min", NON_ZERO,
+	// "each:match". These take precedence over the Message
+	Messages map[string]string
+
+	// Checks the field only if the condition holds, e.g. FieldEq("CustomerType", 2)
+	When Condition
+
+	// Skips the field if the condition holds, e.g. FieldEmpty("Email")
+	Unless Condition
 }
 
 type Filter []FilterItem
 
 // Checks the fields of the structure according to the specified rules.
-// Returns false or true, respectively
+// Returns false or true, respectively. Stops at the first error
 func (filter Filter) IsValid(data any) bool {
-	return len(filter.Validate(data)) == 0
+	return std.IsValid(filter, data)
 }
 
 // Checks the fields of the structure according to the specified rules.
 // Returns a slice with error hints if at least one field is not valid,
 // otherwise, it will return an empty slice
 func (filter Filter) Validate(data any) []string {
-	refValData := reflect.Indirect(reflect.ValueOf(data))
-	refTypData := refValData.Type()
+	return filter.Errors(data).Hints()
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns ValidationErrors if at least one field is not valid, otherwise nil
+func (filter Filter) Check(data any) error {
+	if errs := filter.Errors(data); len(errs) > 0 {
+		return errs
+	}
 
-	hints := make([]string, 0, refTypData.NumField())
+	return nil
+}
+
+// Checks the fields of the structure according to the specified rules.
+// Returns a slice with the detailed errors if at least one field is not valid,
+// otherwise, it will return an empty slice
+func (filter Filter) Errors(data any) ValidationErrors {
+	return std.Errors(filter, data)
+}
+
+// Collects the errors within the limits of the Validator options,
+// followed by the ErrTruncated error if the validation stopped early
+func (v *Validator) errors(filter Filter, data reflect.Value, fields []compiledField) ValidationErrors {
+	errs, truncated := v.collect(filter, data, fields, v.maxErrors, v.failFast)
+
+	if truncated {
+		errs = append(errs, newError(MsgTruncated))
+	}
+
+	return errs
+}
+
+// Collects the errors of the filter items. Stops once the number of the errors
+// reaches the limit, unless it is zero, or at the first failed item on failFast.
+// Reports whether any of the items remained unchecked or any error was dropped.
+// The data is the structure (or the map) the conditions of the items refer to.
+// The fields of the compiled filter are resolved, otherwise nil
+func (v *Validator) collect(filter Filter, data reflect.Value, fields []compiledField, limit int, failFast bool) (ValidationErrors, bool) {
+	size := len(filter)
+	if limit > 0 && limit < size {
+		size = limit
+	}
+
+	errs := make(ValidationErrors, 0, size)
 	successFields := 0
+	truncated := false
 
-	for _, filterStruct := range filter {
-		// field := refTypData.Field(i)
-		// field, fieldExist := refTypData.FieldByName(filterStruct.Field)
-		rules := reflect.Indirect(reflect.ValueOf(filterStruct.Check))
+	for n, filterStruct := range filter {
+		if failFast && len(errs) > 0 || limit > 0 && len(errs) >= limit {
+			truncated = true
+			break
+		}
 
-		if field, exist := refTypData.FieldByName(filterStruct.Field); exist {
-			tagName := filterStruct.Field
-			// tagName = field.Tag.Get("json")
+		if !v.applies(filterStruct, data) {
+			continue
+		}
 
-			if tagNameJson, exist := field.Tag.Lookup("json"); exist {
-				tagName = tagNameJson
-			}
+		var (
+			value   reflect.Value
+			tagName string
+			exist   bool
+		)
 
-			value := refValData.FieldByName(filterStruct.Field)
+		if fields != nil {
+			value, tagName, exist = fields[n].lookup(v, data, filterStruct.Field)
+		} else {
+			value, tagName, exist = v.lookupField(data, filterStruct.Field)
+		}
 
-			if filterStruct.Optional && value.IsZero() {
+		if exist {
+			if filterStruct.Optional && (!value.IsValid() || value.IsZero()) {
 				continue
 			}
 
-			if hint := checkField(rules, value); hint != "" {
-				hints = append(hints, tagName+" "+hint)
+			if fieldErrs := v.checkField(filterStruct.Check, data, value); len(fieldErrs) > 0 {
+				for _, err := range fieldErrs {
+					if err.Field == "" {
+						filterStruct.render(err, tagName)
+					}
+
+					// errors of the sub-filters come with a relative path, e.g. "[3].sku"
+					err.Field = tagName + err.Field
+					err.Path = filterStruct.Field + err.Path
+				}
+
+				errs = append(errs, fieldErrs...)
 			} else {
 				successFields++
 			}
@@ -93,88 +232,561 @@ func (filter Filter) Validate(data any) []string {
 			continue
 		}
 
-		if hint := checkOthers(rules, successFields); hint != "" {
-			hints = append(hints, hint)
+		if err := v.checkOthers(filterStruct.Check, data, successFields); err != nil {
+			filterStruct.render(err, "")
+			errs = append(errs, err)
 		}
 	}
 
-	return hints
+	// a single item might have failed several rules, e.g. AllOf
+	if limit > 0 && len(errs) > limit {
+		errs, truncated = errs[:limit], true
+	}
+
+	return errs, truncated
 }
 
-func checkField(rules, value reflect.Value) string {
-	switch rules.String() {
-	case "<validator.Group Value>":
+// Renders the template of the hint in place of the default message. The errors
+// of the misconfigured rules keep their messages, so they do not go unnoticed
+func (item FilterItem) render(err *ValidationError, field string) {
+	template, found := item.Messages[err.Action]
+	if !found {
+		template = item.Message
+	}
 
-		for n := 0; n < rules.Len(); n++ {
-			item := reflect.Indirect(reflect.ValueOf(
-				rules.Index(n).Interface(),
-			))
+	if template == "" || err.kind == ErrInvalidRule {
+		return
+	}
 
-			if hint := checkField(item, value); hint != "" {
-				return hint
-			}
+	protoMin, protoMax := err.Proto, err.Proto
+
+	if proto := reflect.ValueOf(err.Proto); proto.Kind() == reflect.Array || proto.Kind() == reflect.Slice {
+		if proto.Len() == 2 {
+			protoMin, protoMax = proto.Index(0).Interface(), proto.Index(1).Interface()
 		}
+	}
 
+	err.Message = strings.NewReplacer(
+		"{field}", field,
+		"{value}", sprintNonNil(err.Value),
+		"{proto}", sprintNonNil(err.Proto),
+		"{min}", sprintNonNil(protoMin),
+		"{max}", sprintNonNil(protoMax),
+	).Replace(template)
+
+	// the message is the whole hint, and is not subject to the catalogs
+	err.Code, err.Args = "", nil
+	err.template = true
+}
+
+func sprintNonNil(value any) string {
+	if value == nil {
 		return ""
+	}
 
-	case "<validator.Range Value>":
-		return compare("range", rules, value)
+	return fmt.Sprint(value)
+}
 
-	case "<validator.Rule Value>":
-		action := rules.Index(0).Elem().String()
-		proto := rules.Index(1).Elem()
+// Looks up the field of the structure, or the key of the map, by its name or
+// by a dotted path (e.g. "Address.City") that walks into the nested structures,
+// maps, and pointers. Returns the value of the field, its hint name joined by
+// the json tags or the map keys (e.g. "address.city"), and whether the field
+// exists. The value is invalid when a pointer on the path is nil, or when the
+// key is missing from the map. The invalid data has no fields
+func (v *Validator) lookupField(data reflect.Value, path string) (reflect.Value, string, bool) {
+	if path == "" || !data.IsValid() {
+		return refNil, "", false
+	}
 
-		return compare(action, proto, value)
+	typ := data.Type()
+	tagName := ""
 
-	case NON_ZERO:
-		action := rules.String()
-		proto := reflect.ValueOf(nil)
+	for {
+		name, rest, nested := strings.Cut(path, ".")
+
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Interface {
+			if !data.IsValid() || data.IsNil() {
+				if typ.Kind() == reflect.Interface {
+					// the dynamic type is unknown, so the rest of the path is missing
+					return refNil, joinName(tagName, path), true
+				}
+
+				typ = typ.Elem()
+				data = refNil
+				continue
+			}
+
+			data = data.Elem()
+			typ = data.Type()
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, exist := typ.FieldByName(name)
+			if !exist {
+				return refNil, "", false
+			}
+
+			tagName = joinName(tagName, v.fieldName(field))
+			typ = field.Type
+
+			if data.IsValid() {
+				// an embedded pointer on the way might be nil
+				data, _ = data.FieldByIndexErr(field.Index)
+			}
+
+		case reflect.Map:
+			if typ.Key().Kind() != reflect.String {
+				return refNil, "", false
+			}
+
+			tagName = joinName(tagName, name)
+
+			if data.IsValid() {
+				data = data.MapIndex(reflect.ValueOf(name).Convert(typ.Key()))
 
-		return compare(action, proto, value)
+				if !nested && data.Kind() == reflect.Interface {
+					// the value of map[string]any, where nil becomes invalid
+					data = data.Elem()
+				}
+			}
+
+			typ = typ.Elem()
+
+		default:
+			return refNil, "", false
+		}
+
+		if !nested {
+			return data, tagName, true
+		}
+
+		path = rest
+	}
+}
+
+func joinName(path, name string) string {
+	if path == "" {
+		return name
 	}
 
-	return MsgInvalidRule
+	return path + "." + name
 }
 
-func checkOthers(rules reflect.Value, successFields int) string {
+// Checks the value of the field according to the rules. The data is
+// the structure (or the map) the field references of the rules refer to
+func (v *Validator) checkField(rules any, data, value reflect.Value) ValidationErrors {
+	switch rules := rules.(type) {
+	case Group:
+		for _, item := range rules {
+			if errs := v.checkField(item, data, value); len(errs) > 0 {
+				return errs
+			}
+		}
+
+		return nil
+
+	case AllOf:
+		var errs ValidationErrors
+
+		for _, item := range rules {
+			errs = append(errs, v.checkField(item, data, value)...)
+		}
+
+		return errs
+
+	case AnyOf:
+		return v.checkAlternatives("AnyOf", rules, data, value)
+
+	case OneOf:
+		return v.checkAlternatives("OneOf", rules, data, value)
+
+	case Not:
+		errs := v.checkField(rules[0], data, value)
+
+		switch {
+		case inapplicable(errs) != nil:
+			return ValidationErrors{inapplicable(errs)}
+
+		case len(errs) > 0:
+			return nil
+		}
+
+		err := v.negate(rules[0], data, value)
+		err.describe("Not", reflect.ValueOf(rules[0]), value)
+
+		return ValidationErrors{err}
+
+	case Each:
+		return v.checkEach(Filter(rules), nil, value)
+
+	case compiledEach:
+		return v.checkEach(rules.filter, rules.fields, value)
+
+	case fastRule:
+		if rules.pass(value) {
+			return nil
+		}
+
+		return v.checkField(rules.rule, data, value)
+
+	case Range:
+		if hasRef(rules[:]) {
+			return single(v.compareRef("range", rules[:], data, value))
+		}
+
+		return single(v.compare("range", reflect.ValueOf(rules), value))
+
+	case Rule:
+		action, _ := rules[0].(string)
+		proto := reflect.ValueOf(rules[1])
+
+		if hasRef(rules[1:]) {
+			return single(v.compareRef(action, rules[1:], data, value))
+		}
+
+		return single(v.compare(action, proto, value))
+
+	case string:
+		if rules == NON_ZERO {
+			return single(v.compare(rules, refNil, value))
+		}
+
+	default:
+		// a pointer to the rule, e.g. &Group{}
+		if ptr := reflect.ValueOf(rules); ptr.Kind() == reflect.Pointer && !ptr.IsNil() {
+			return v.checkField(ptr.Elem().Interface(), data, value)
+		}
+	}
+
+	return ValidationErrors{newError(MsgInvalidRule)}
+}
+
+// Checks each element of the array, slice, or map with the sub-filter.
+// Returns the errors with paths relative to the field, e.g. "[3].sku".
+// The fields of the compiled sub-filter are resolved, otherwise nil
+func (v *Validator) checkEach(filter Filter, fields []compiledField, value reflect.Value) ValidationErrors {
+	var errs ValidationErrors
+
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice:
+		for n := 0; n < value.Len(); n++ {
+			errs = append(errs, v.checkEachItem(filter, fields, fmt.Sprintf("[%v]", n), value.Index(n))...)
+		}
+
+		return errs
+
+	case reflect.Map:
+		keys := value.MapKeys()
+
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		for _, key := range keys {
+			index := fmt.Sprintf("[%v]", key.Interface())
+			errs = append(errs, v.checkEachItem(filter, fields, index, value.MapIndex(key))...)
+		}
+
+		return errs
+
+	case reflect.Invalid:
+		return ValidationErrors{newError(MsgInvalidValue)}
+	}
+
+	return ValidationErrors{newError(MsgUnsupportType)}
+}
+
+func (v *Validator) checkEachItem(filter Filter, fields []compiledField, index string, item reflect.Value) ValidationErrors {
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return ValidationErrors{itemError(index, MsgInvalidValue)}
+		}
+
+		item = item.Elem()
+	}
+
+	if item.Kind() != reflect.Struct && item.Kind() != reflect.Map {
+		return ValidationErrors{itemError(index, MsgUnsupportType)}
+	}
+
+	// the limits of the Validator options apply to the whole structure only
+	errs, _ := v.collect(filter, item, fields, 0, false)
+
+	for _, err := range errs {
+		if err.Field == "" {
+			// an error of the whole item, e.g. "invalid body value"
+			err.Field, err.Path = index, index
+			continue
+		}
+
+		err.Field = index + "." + err.Field
+		err.Path = index + "." + err.Path
+	}
+
+	return errs
+}
+
+// Returns the error of the whole item of the collection, e.g. "[3]"
+func itemError(index, message string) *ValidationError {
+	err := newError(message)
+	err.Field, err.Path = index, index
+
+	return err
+}
+
+// Checks the alternative rules of the AnyOf and the OneOf. Each failed rule
+// is represented by its first error, e.g. the first failed rule of the Group
+func (v *Validator) checkAlternatives(action string, rules []any, data, value reflect.Value) ValidationErrors {
+	if len(rules) == 0 {
+		return ValidationErrors{newError(MsgInvalidRule)}
+	}
+
 	var (
-		action = ""
-		value  = reflect.ValueOf(nil)
-		proto  reflect.Value
+		causes     ValidationErrors
+		unsuitable *ValidationError
 	)
 
-	switch rules.String() {
-	case "<validator.Rule Value>":
-		action = rules.Index(0).Elem().String()
-		proto = rules.Index(1).Elem()
+	passed := 0
+
+	// every alternative is checked, so the result does not depend on their order
+	for _, item := range rules {
+		errs := v.checkField(item, data, value)
+
+		switch err := inapplicable(errs); {
+		case err != nil && err.kind == ErrInvalidRule:
+			// a misconfigured rule is reported rather than taken as a failed alternative
+			return ValidationErrors{err}
+
+		case err != nil:
+			// an unsuitable value is reported only if no alternative passes
+			if unsuitable == nil {
+				unsuitable = err
+			}
+
+		case len(errs) > 0:
+			// the same hints are joined once, e.g. of the two "match" rules
+			if !hasMessage(causes, errs[0].Message) {
+				causes = append(causes, errs[0])
+			}
+
+		default:
+			passed++
+		}
+	}
+
+	var err *ValidationError
+
+	switch {
+	case passed == 1 || passed > 1 && action == "AnyOf":
+		return nil
+
+	case passed > 1:
+		err = newError(MsgOneOf)
+
+	case unsuitable != nil:
+		return ValidationErrors{unsuitable}
+
+	default:
+		err = joinCauses(causes)
+	}
+
+	err.describe(action, reflect.ValueOf(rules), value)
+
+	return ValidationErrors{err}
+}
+
+// Joins the errors of the failed alternatives, e.g. "is empty or is not valid"
+func joinCauses(causes ValidationErrors) *ValidationError {
+	err := newError(MsgNotValid)
+	err.Code, err.causes = messageCodes[MsgAnyOf], causes
+	err.Message = joinMessages(MsgAnyOf, causes)
+
+	return err
+}
+
+func joinMessages(format string, causes ValidationErrors) string {
+	message := causes[0].String()
+
+	for _, cause := range causes[1:] {
+		message = fmt.Sprintf(format, message, cause.String())
+	}
+
+	return message
+}
+
+func hasMessage(errs ValidationErrors, message string) bool {
+	for _, err := range errs {
+		if err.Message == message {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The rules whose failure means the opposite rule passes
+var oppositeRules = map[string]string{
+	"in":           "notIn",
+	"notIn":        "in",
+	"contains":     "notContains",
+	"notContains":  "contains",
+	"icontains":    "inotContains",
+	"inotContains": "icontains",
+}
+
+// Describes the rule that passed, while it must not, e.g. "must not be one of 1, 2"
+// of the Not{Rule{"in", []int{1, 2}}}. The rule without the opposite message
+// is named by its action, e.g. "must not pass the min rule"
+func (v *Validator) negate(rules any, data, value reflect.Value) *ValidationError {
+	var action string
+	var proto any
+
+	switch rules := rules.(type) {
+	case Rule:
+		action, _ = rules[0].(string)
+		proto = rules[1]
+
+	case Range:
+		action = "range"
+
+	default:
+		return newError(MsgNotValid)
+	}
+
+	if opposite, found := oppositeRules[action]; found {
+		if errs := v.checkField(Rule{opposite, proto}, data, value); len(errs) > 0 {
+			err := errs[0]
+			err.Proto = nil
+
+			return err
+		}
+	}
+
+	switch action {
+	case "prefix", "iprefix":
+		return newError(MsgNotPrefix, proto)
+
+	case "suffix", "isuffix":
+		return newError(MsgNotSuffix, proto)
+
+	case "match":
+		return newError(MsgNotMatch, proto)
+	}
+
+	return newError(MsgNotRule, action)
+}
+
+// Returns the error that tells the rule is not applicable rather than failed:
+// the misconfigured rule, the unsupported type or the invalid value, if any
+func inapplicable(errs ValidationErrors) *ValidationError {
+	for _, err := range errs {
+		switch err.kind {
+		case ErrInvalidRule, ErrUnsupportType, ErrInvalidValue:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func single(err *ValidationError) ValidationErrors {
+	if err == nil {
+		return nil
+	}
+
+	return ValidationErrors{err}
+}
+
+func (v *Validator) checkOthers(rules any, data reflect.Value, successFields int) *ValidationError {
+	switch rules := rules.(type) {
+	case FieldSet:
+		return v.checkFieldSet(rules, data)
+
+	case *FieldSet:
+		if rules != nil {
+			return v.checkFieldSet(*rules, data)
+		}
+
+		return newError(MsgInvalidRule)
+
+	case Rule:
+		action, _ := rules[0].(string)
+		proto := reflect.ValueOf(rules[1])
+		value := refNil
 
 		if strings.HasPrefix(action, "fields:") {
 			action = action[7:]
 			value = reflect.ValueOf(successFields)
 		}
 
-		if hint := compare(action, proto, value); hint != "" {
-			return MsgInvalidBodyVal
+		if err := v.compare(action, proto, value); err != nil {
+			return newError(MsgInvalidBodyVal)
 		}
 
+	case *Rule:
+		if rules != nil {
+			return v.checkOthers(*rules, data, successFields)
+		}
+
+		return newError(MsgInvalidRule)
+
 	default:
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
+	}
+
+	return nil
+}
+
+func (v *Validator) compare(action string, proto, value reflect.Value) *ValidationError {
+	err := v.filterAction(action, proto, value)
+
+	if err != nil {
+		err.describe(action, proto, value)
 	}
 
-	return ""
+	return err
 }
 
-func compare(action string, proto, value reflect.Value) string {
+func (v *Validator) filterAction(action string, proto, value reflect.Value) *ValidationError {
 	switch action {
 	case NON_ZERO:
-		if value.IsZero() {
-			return MsgEmpty
+		if !value.IsValid() || value.IsZero() {
+			return newError(MsgEmpty)
 		}
-		return ""
+		return nil
+
+	case "utf8":
+		return filterUTF8(proto, value)
+	}
+
+	// the custom rules decide on the prototype themselves, e.g. Rule{"sku", nil}
+	if fn, found := v.rule(action); found {
+		if message := fn(proto, value); message != "" {
+			return newError(message)
+		}
+		return nil
+	}
+
+	// modifiers, including the custom rules, e.g. "each:sku"
+	if strings.HasPrefix(action, "each:") {
+		return v.filterEach(action[5:], proto, value)
+	}
+
+	// registered modifiers, e.g. "trim:min", "keys:match"
+	if name, rest, found := strings.Cut(action, ":"); found {
+		if fn, found := v.modifier(name); found {
+			return v.filterModifier(fn, rest, proto, value)
+		}
+	}
+
+	// the character classes take no prototype, e.g. Rule{"alpha", nil}
+	if class, found := charClasses[action]; found {
+		return filterCharClass(class, proto, value)
 	}
 
 	if !proto.IsValid() {
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
 	}
 
 	switch action {
@@ -190,13 +802,16 @@ func compare(action string, proto, value reflect.Value) string {
 	case "eq":
 		return filterEq(proto, value)
 
+	case "in", "notIn":
+		return filterIn(action, proto, value)
+
+	case "contains", "notContains", "prefix", "suffix",
+		"icontains", "inotContains", "iprefix", "isuffix":
+		return filterContains(action, proto, value)
+
 	case "match":
 		return filterMatch(proto, value)
 
-	// modifiers
-	case "each:range", "each:min", "each:max", "each:eq", "each:match":
-		return filterEach(action[5:], proto, value)
-
 	case "date:min", "date:max", "date:eq":
 		return filterDate(action[5:], proto, value)
 
@@ -207,188 +822,211 @@ func compare(action string, proto, value reflect.Value) string {
 		return filterYearEqual(proto, value)
 
 	default:
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
 	}
 }
 
-func filterRange(proto, value reflect.Value) string {
-	hint := ""
+func filterRange(proto, value reflect.Value) *ValidationError {
+	var err *ValidationError
 
 	valMin := proto.Index(0)
 	valMax := proto.Index(1)
 
 	if valMin.Equal(refNil) || valMax.Equal(refNil) {
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
 	}
 
 	switch value.Kind() {
 	case reflect.String:
 		value = reflect.ValueOf(utf8.RuneCountInString(value.String()))
-		hint = fmt.Sprintf(MsgRangeStrLen, valMin.Interface(), valMax.Interface())
+		err = newError(MsgRangeStrLen, valMin.Interface(), valMax.Interface())
 
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
 		value = reflect.ValueOf(value.Len())
-		hint = fmt.Sprintf(MsgRangeSetLen, valMin.Interface(), valMax.Interface())
+		err = newError(MsgRangeSetLen, valMin.Interface(), valMax.Interface())
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		hint = fmt.Sprintf(MsgRange, valMin.Interface(), valMax.Interface())
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		err = newError(MsgRange, valMin.Interface(), valMax.Interface())
 
 	case reflect.Invalid:
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 
 	default:
-		hint = MsgUnsupportType
+		err = newError(MsgUnsupportType)
 	}
 
 	if !IsMin(valMin.Interface(), value.Interface()) {
-		return hint
+		return err
 	}
 	if !IsMax(valMax.Interface(), value.Interface()) {
-		return hint
+		return err
 	}
 
-	return ""
+	return nil
 }
 
-func filterMin(proto, value reflect.Value) string {
-	hint := ""
+func filterMin(proto, value reflect.Value) *ValidationError {
+	var err *ValidationError
 
 	switch value.Kind() {
 	case reflect.String:
 		value = reflect.ValueOf(utf8.RuneCountInString(value.String()))
-		hint = fmt.Sprintf(MsgMinStrLen, proto.Interface())
+		err = newError(MsgMinStrLen, proto.Interface())
 
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
 		value = reflect.ValueOf(value.Len())
-		hint = fmt.Sprintf(MsgMinSetLen, proto.Interface())
+		err = newError(MsgMinSetLen, proto.Interface())
 
 	case reflect.Invalid:
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 
 	default:
-		hint = fmt.Sprintf(MsgMin, proto.Interface())
+		err = newError(MsgMin, proto.Interface())
 	}
 
 	if !IsMin(proto.Interface(), value.Interface()) {
-		return hint
+		return err
 	}
 
-	return ""
+	return nil
 }
 
-func filterMax(proto, value reflect.Value) string {
-	hint := ""
+func filterMax(proto, value reflect.Value) *ValidationError {
+	var err *ValidationError
 
 	switch value.Kind() {
 	case reflect.String:
 		value = reflect.ValueOf(utf8.RuneCountInString(value.String()))
-		hint = fmt.Sprintf(MsgMaxStrLen, proto.Interface())
+		err = newError(MsgMaxStrLen, proto.Interface())
 
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
 		value = reflect.ValueOf(value.Len())
-		hint = fmt.Sprintf(MsgMaxSetLen, proto.Interface())
+		err = newError(MsgMaxSetLen, proto.Interface())
 
 	case reflect.Invalid:
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 
 	default:
-		hint = fmt.Sprintf(MsgMax, proto.Interface())
+		err = newError(MsgMax, proto.Interface())
 	}
 
 	if !IsMax(proto.Interface(), value.Interface()) {
-		return hint
+		return err
 	}
 
-	return ""
+	return nil
 }
 
-func filterEq(proto, value reflect.Value) string {
-	hint := ""
+func filterEq(proto, value reflect.Value) *ValidationError {
+	var err *ValidationError
 
 	switch value.Kind() {
 	case reflect.String:
 		value = reflect.ValueOf(utf8.RuneCountInString(value.String()))
-		hint = fmt.Sprintf(MsgEqStrLen, proto.Interface())
+		err = newError(MsgEqStrLen, proto.Interface())
 
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
 		value = reflect.ValueOf(value.Len())
-		hint = fmt.Sprintf(MsgEqSetLen, proto.Interface())
+		err = newError(MsgEqSetLen, proto.Interface())
 
 	case reflect.Invalid:
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 
 	default:
-		hint = fmt.Sprintf(MsgEq, proto.Interface())
+		err = newError(MsgEq, proto.Interface())
 	}
 
 	if !IsEqual(proto.Interface(), value.Interface()) {
-		return hint
+		return err
 	}
 
-	return ""
+	return nil
 }
 
-func filterYearEqual(proto, value reflect.Value) string {
+func filterYearEqual(proto, value reflect.Value) *ValidationError {
 	switch value.String() {
 	case "<time.Time Value>":
 		if !IsEqual(proto.Interface(), value.Interface().(time.Time).Year()) {
-			return fmt.Sprintf(MsgEq, proto.Interface())
+			return newError(MsgEq, proto.Interface())
 		}
 
 	default:
-		return MsgUnsupportType
+		return newError(MsgUnsupportType)
 	}
 
-	return ""
+	return nil
 }
 
-func filterEach(action string, proto, value reflect.Value) string {
+func (v *Validator) filterEach(action string, proto, value reflect.Value) *ValidationError {
 	switch action {
 	case "match":
+		if !proto.IsValid() {
+			return newError(MsgInvalidRule)
+		}
+
+		if _, ok := proto.Interface().(*regexp.Regexp); ok {
+			break
+		}
+
 		if (proto.Kind() != reflect.String) || (proto.Len() == 0) {
-			return MsgInvalidRule
+			return newError(MsgInvalidRule)
 		}
 	}
 
 	switch value.Kind() {
 	case reflect.Array, reflect.Slice:
 		for n := 0; n < value.Len(); n++ {
-			if hint := compare(action, proto, value.Index(n)); hint != "" {
-				if hint != MsgInvalidRule {
-					hint = fmt.Sprintf("item[%v] "+hint, n)
+			if err := v.compare(action, proto, value.Index(n)); err != nil {
+				if err.Message != MsgInvalidRule {
+					err.Message = fmt.Sprintf("item[%v] ", n) + err.Message
 				}
 
-				return hint
+				return err
 			}
 		}
 
-		return ""
+		return nil
 
 	case reflect.Map:
 		iter := value.MapRange()
 
 		for iter.Next() {
-			if hint := compare(action, proto, iter.Value()); hint != "" {
-				if hint != MsgInvalidRule {
-					hint = fmt.Sprintf("item[%v] "+hint, iter.Key())
+			if err := v.compare(action, proto, iter.Value()); err != nil {
+				if err.Message != MsgInvalidRule {
+					err.Message = fmt.Sprintf("item[%v] ", iter.Key()) + err.Message
 				}
 
-				return hint
+				return err
 			}
 		}
 
-		return ""
+		return nil
 	}
 
-	return MsgUnsupportType
+	return newError(MsgUnsupportType)
+}
+
+// Applies the modifier to the rest of the action, which might be
+// a chain of the modifiers, e.g. "trim:min" of the "each:trim:min"
+func (v *Validator) filterModifier(fn ModifierFunc, action string, proto, value reflect.Value) *ValidationError {
+	err := fn(value, func(value reflect.Value) error {
+		if err := v.compare(action, proto, value); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return asValidationError(err)
 }
 
-func filterDate(action string, proto, value reflect.Value) string {
+func filterDate(action string, proto, value reflect.Value) *ValidationError {
 	var tmProto, tmValue int64
 
 	if value.Equal(refNil) {
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 	}
 
 	switch proto.Type().String() + ":" + value.Type().String() {
@@ -403,45 +1041,45 @@ func filterDate(action string, proto, value reflect.Value) string {
 	case "string:time.Time":
 		t, err := time.Parse(time.RFC3339, proto.String())
 		if err != nil {
-			return MsgInvalidRule
+			return newError(MsgInvalidRule)
 		}
 
 		tmProto = t.Unix()
 		tmValue = value.Interface().(time.Time).Unix()
 
 	default:
-		return MsgUnsupportType
+		return newError(MsgUnsupportType)
 	}
 
 	switch action {
 	case "min":
 		if tmValue < tmProto {
-			return fmt.Sprintf(MsgMin, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
+			return newError(MsgMin, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
 		}
 
 	case "max":
 		if tmValue > tmProto {
-			return fmt.Sprintf(MsgMax, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
+			return newError(MsgMax, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
 		}
 
 	case "eq":
 		if tmValue != tmProto {
-			return fmt.Sprintf(MsgEq, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
+			return newError(MsgEq, time.Unix(tmProto, 0).UTC().Format(time.RFC3339))
 		}
 
 	default:
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
 	}
 
-	return ""
+	return nil
 }
 
-func filterTime(action string, proto, value reflect.Value) string {
+func filterTime(action string, proto, value reflect.Value) *ValidationError {
 	var tmProto, tmValue int64
 	var err error
 
 	if value.Equal(refNil) {
-		return MsgInvalidValue
+		return newError(MsgInvalidValue)
 	}
 
 	switch proto.Type().String() + ":" + value.Type().String() {
@@ -458,46 +1096,564 @@ func filterTime(action string, proto, value reflect.Value) string {
 		tmProto, err = strconv.ParseInt(proto.String(), 10, 64)
 
 		if err != nil {
-			return MsgInvalidRule
+			return newError(MsgInvalidRule)
 		}
 
 	default:
-		return MsgUnsupportType
+		return newError(MsgUnsupportType)
 	}
 
 	switch action {
 	case "min":
 		if tmValue < tmProto {
-			return fmt.Sprintf(MsgMin, tmProto)
+			return newError(MsgMin, tmProto)
 		}
 
 	case "max":
 		if tmValue > tmProto {
-			return fmt.Sprintf(MsgMax, tmProto)
+			return newError(MsgMax, tmProto)
 		}
 
 	case "eq":
 		if tmValue != tmProto {
-			return fmt.Sprintf(MsgEq, tmProto)
+			return newError(MsgEq, tmProto)
 		}
 
 	default:
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
+	}
+
+	return nil
+}
+
+// Checks whether the value is one of the prototype values, e.g. Rule{"in", []any{1, 2}}.
+// The numbers are compared by their values regardless of the types, like IsEqual does
+func filterIn(action string, proto, value reflect.Value) *ValidationError {
+	if (proto.Kind() != reflect.Array && proto.Kind() != reflect.Slice) || proto.Len() == 0 {
+		return newError(MsgInvalidRule)
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+
+	case reflect.Invalid:
+		return newError(MsgInvalidValue)
+
+	default:
+		return newError(MsgUnsupportType)
+	}
+
+	found := false
+	values := make([]string, proto.Len())
+
+	for n := range values {
+		item := proto.Index(n)
+		if item.Kind() == reflect.Interface {
+			item = item.Elem()
+		}
+
+		if !item.IsValid() || !item.CanInterface() {
+			continue
+		}
+
+		values[n] = fmt.Sprint(item.Interface())
+
+		switch {
+		case found:
+
+		case value.Kind() == reflect.String:
+			// the defined string types, e.g. type Status string
+			found = item.Kind() == reflect.String && item.String() == value.String()
+
+		default:
+			found = IsEqual(item.Interface(), value.Interface())
+		}
+	}
+
+	switch {
+	case action == "in" && !found:
+		return newError(MsgIn, strings.Join(values, ", "))
+
+	case action == "notIn" && found:
+		return newError(MsgNotIn, strings.Join(values, ", "))
+	}
+
+	return nil
+}
+
+// Checks the substring of the string, e.g. Rule{"prefix", "https://"}.
+// The actions starting with "i" ignore the case, e.g. "icontains"
+func filterContains(action string, proto, value reflect.Value) *ValidationError {
+	if proto.Kind() != reflect.String || proto.Len() == 0 {
+		return newError(MsgInvalidRule)
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+
+	case reflect.Invalid:
+		return newError(MsgInvalidValue)
+
+	default:
+		return newError(MsgUnsupportType)
+	}
+
+	substr, str := proto.String(), value.String()
+
+	// the case-insensitive variants, e.g. "icontains", "inotContains"
+	if action[0] == 'i' {
+		action = action[1:]
+		substr, str = strings.ToLower(substr), strings.ToLower(str)
 	}
 
-	return ""
+	switch action {
+	case "contains":
+		if !strings.Contains(str, substr) {
+			return newError(MsgContains, proto.String())
+		}
+
+	case "notContains":
+		if strings.Contains(str, substr) {
+			return newError(MsgNotContains, proto.String())
+		}
+
+	case "prefix":
+		if !strings.HasPrefix(str, substr) {
+			return newError(MsgPrefix, proto.String())
+		}
+
+	case "suffix":
+		if !strings.HasSuffix(str, substr) {
+			return newError(MsgSuffix, proto.String())
+		}
+	}
+
+	return nil
 }
 
-func filterMatch(reg, value reflect.Value) string {
-	match, err := regexp.MatchString(reg.String(), value.String())
+// Allows the runes of the string, optionally restricted to ASCII
+type charClass struct {
+	allows, allowsASCII   func(r rune) bool
+	message, messageASCII string
+}
+
+// The character class rules, where the Unicode letters, digits, etc. are
+// told by the unicode package
+var charClasses = map[string]charClass{
+	"alpha": {
+		allows:       unicode.IsLetter,
+		allowsASCII:  isASCIILetter,
+		message:      MsgAlpha,
+		messageASCII: MsgAlphaASCII,
+	},
+	"alnum": {
+		allows:       func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+		allowsASCII:  func(r rune) bool { return isASCIILetter(r) || isASCIIDigit(r) },
+		message:      MsgAlnum,
+		messageASCII: MsgAlnumASCII,
+	},
+	"numeric": {
+		allows:       unicode.IsDigit,
+		allowsASCII:  isASCIIDigit,
+		message:      MsgNumeric,
+		messageASCII: MsgNumeric,
+	},
+	"ascii": {
+		allows:  func(r rune) bool { return r < utf8.RuneSelf },
+		message: MsgASCII,
+	},
+	"printable": {
+		allows:       unicode.IsPrint,
+		allowsASCII:  func(r rune) bool { return r >= ' ' && r <= '~' },
+		message:      MsgPrintable,
+		messageASCII: MsgPrintableASCII,
+	},
+	"lower": {
+		allows:  func(r rune) bool { return !unicode.IsUpper(r) && !unicode.IsTitle(r) },
+		message: MsgLower,
+	},
+	"upper": {
+		allows:  func(r rune) bool { return !unicode.IsLower(r) },
+		message: MsgUpper,
+	},
+	"noSpace": {
+		allows:  func(r rune) bool { return !unicode.IsSpace(r) },
+		message: MsgNoSpace,
+	},
+}
+
+func isCharClass(action string) bool {
+	_, found := charClasses[action]
+	return found
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Checks that each rune of the string is allowed by the class.
+// The empty string passes, so it takes NON_ZERO to require the value
+func filterCharClass(class charClass, proto, value reflect.Value) *ValidationError {
+	allows, message := class.allows, class.message
+
+	switch {
+	case !proto.IsValid():
+
+	case proto.Kind() == reflect.String && proto.String() == ASCII && class.allowsASCII != nil:
+		allows, message = class.allowsASCII, class.messageASCII
+
+	default:
+		return newError(MsgInvalidRule)
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+
+	case reflect.Invalid:
+		return newError(MsgInvalidValue)
+
+	default:
+		return newError(MsgUnsupportType)
+	}
+
+	for _, r := range value.String() {
+		if !allows(r) {
+			return newError(message)
+		}
+	}
+
+	return nil
+}
+
+// Checks that the string is valid UTF-8, which the length rules do not,
+// e.g. the string of the invalid bytes has the length as well
+func filterUTF8(proto, value reflect.Value) *ValidationError {
+	if proto.IsValid() {
+		return newError(MsgInvalidRule)
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if !utf8.ValidString(value.String()) {
+			return newError(MsgUTF8)
+		}
+
+		return nil
+
+	case reflect.Invalid:
+		return newError(MsgInvalidValue)
+	}
+
+	return newError(MsgUnsupportType)
+}
+
+func filterMatch(reg, value reflect.Value) *ValidationError {
+	// the nil pointer has no string to match
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return newError(MsgInvalidValue)
+	}
+
+	var (
+		re  *regexp.Regexp
+		err error
+	)
+
+	if proto, ok := reg.Interface().(*regexp.Regexp); ok {
+		// precompiled by the user or by CompiledFilter
+		if proto == nil {
+			return newError(MsgInvalidRule)
+		}
+
+		re = proto
+	} else {
+		re, err = patterns.compile(reg.String())
+	}
 
 	switch {
 	case err != nil:
-		return MsgInvalidRule
+		return newError(MsgInvalidRule)
+
+	case !re.MatchString(value.String()):
+		return newError(MsgNotValid)
+	}
+
+	return nil
+}
+
+// Maximum number of the compiled patterns kept by the cache
+const patternsCacheSize = 256
+
+var patterns = &patternCache{items: map[string]patternEntry{}}
+
+// Keeps the compiled regular expressions of the "match" rules, so that
+// the pattern is compiled once rather than on each check of the value.
+// The oldest patterns are evicted when the cache is full
+type patternCache struct {
+	mu    sync.RWMutex
+	items map[string]patternEntry
+	order []string
+}
+
+type patternEntry struct {
+	re  *regexp.Regexp
+	err error
+}
+
+func (cache *patternCache) compile(pattern string) (*regexp.Regexp, error) {
+	cache.mu.RLock()
+	entry, found := cache.items[pattern]
+	cache.mu.RUnlock()
+
+	if found {
+		return entry.re, entry.err
+	}
+
+	// the invalid patterns are cached as well, to not recompile them
+	entry.re, entry.err = regexp.Compile(pattern)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if _, found := cache.items[pattern]; !found {
+		if len(cache.order) >= patternsCacheSize {
+			delete(cache.items, cache.order[0])
+			cache.order = cache.order[1:]
+		}
+
+		cache.items[pattern] = entry
+		cache.order = append(cache.order, pattern)
+	}
+
+	return entry.re, entry.err
+}
+
+// The numeric types, including the defined types
+// with the numeric underlying type, e.g. type Age uint8
+type (
+	signed interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64
+	}
+
+	unsigned interface {
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+	}
+
+	float interface {
+		~float32 | ~float64
+	}
+
+	numeric interface {
+		signed | unsigned | float
+	}
+)
+
+type numberKind uint8
+
+const (
+	numSigned numberKind = iota + 1
+	numUnsigned
+	numFloat
+)
+
+// Holds the number of any numeric type in the widest type of its family
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
 
-	case !match:
-		return MsgNotValid
+func numberOf[T numeric](num T) number {
+	var zero, one T = 0, 1
+
+	switch {
+	case one/2 != zero:
+		return number{kind: numFloat, f: float64(num)}
+
+	case zero-one > zero:
+		return number{kind: numUnsigned, u: uint64(num)}
+	}
+
+	return number{kind: numSigned, i: int64(num)}
+}
+
+// Converts the value to the number. The builtin types take the fast path,
+// the defined types are converted by the kind of the underlying type
+func toNumber(value any) (number, bool) {
+	switch num := value.(type) {
+	case int:
+		return numberOf(num), true
+	case int8:
+		return numberOf(num), true
+	case int16:
+		return numberOf(num), true
+	case int32:
+		return numberOf(num), true
+	case int64:
+		return numberOf(num), true
+	case uint:
+		return numberOf(num), true
+	case uint8:
+		return numberOf(num), true
+	case uint16:
+		return numberOf(num), true
+	case uint32:
+		return numberOf(num), true
+	case uint64:
+		return numberOf(num), true
+	case float32:
+		return numberOf(num), true
+	case float64:
+		return numberOf(num), true
+	}
+
+	refValue := reflect.ValueOf(value)
+
+	switch refValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberOf(refValue.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberOf(refValue.Uint()), true
+
+	case reflect.Float32, reflect.Float64:
+		return numberOf(refValue.Float()), true
+	}
+
+	return number{}, false
+}
+
+// Compares the value with the prototype of any numeric types. The mixed
+// signedness and the integers with the floats are compared exactly, rather
+// than through a lossy conversion. Returns -1, 0 or +1 as the value is less
+// than, equal to, or greater than the prototype, and false if either of them
+// is NaN or is not a number
+func compareNumbers(proto, value any) (int, bool) {
+	numProto, ok := toNumber(proto)
+	if !ok {
+		return 0, false
+	}
+
+	numValue, ok := toNumber(value)
+	if !ok {
+		return 0, false
+	}
+
+	return numValue.compare(numProto)
+}
+
+func (a number) compare(b number) (int, bool) {
+	switch {
+	case a.kind == numFloat && b.kind == numFloat:
+		if math.IsNaN(a.f) || math.IsNaN(b.f) {
+			return 0, false
+		}
+
+		return cmpOrdered(a.f, b.f), true
+
+	case a.kind == numFloat:
+		result, ok := b.compare(a)
+		return -result, ok
+
+	case b.kind == numFloat:
+		return a.compareFloat(b.f)
+
+	case a.kind == numSigned && b.kind == numSigned:
+		return cmpOrdered(a.i, b.i), true
+
+	case a.kind == numSigned:
+		if a.i < 0 {
+			return -1, true
+		}
+
+		return cmpOrdered(uint64(a.i), b.u), true
+
+	case b.kind == numSigned:
+		if b.i < 0 {
+			return 1, true
+		}
+
+		return cmpOrdered(a.u, uint64(b.i)), true
+	}
+
+	return cmpOrdered(a.u, b.u), true
+}
+
+// Compares the integer with the float. The bounds of the integer types
+// take care of the infinities
+func (a number) compareFloat(f float64) (int, bool) {
+	if math.IsNaN(f) {
+		return 0, false
+	}
+
+	trunc := math.Trunc(f)
+
+	// the fractional part of the float decides when the integer parts are equal
+	fraction := cmpOrdered(trunc, f)
+
+	if a.kind == numSigned {
+		switch {
+		case f >= math.MaxInt64:
+			return -1, true
+		case f < math.MinInt64:
+			return 1, true
+		}
+
+		if result := cmpOrdered(a.i, int64(trunc)); result != 0 {
+			return result, true
+		}
+
+		return fraction, true
+	}
+
+	switch {
+	case f < 0:
+		return 1, true
+	case f >= math.MaxUint64:
+		return -1, true
+	}
+
+	if result := cmpOrdered(a.u, uint64(trunc)); result != 0 {
+		return result, true
+	}
+
+	return fraction, true
+}
+
+func cmpOrdered[T signed | unsigned | float](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	}
 
-	return ""
+	return 0
 }